@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"product/src/controllers"
 	"product/src/decorators"
+	"product/src/feed"
 	product_nats "product/src/nats"
 	"product/src/nats/subjects"
 
@@ -42,8 +43,13 @@ import (
 
 	migrate "product/src/migrations"
 
+	postgres_attribute_command_handler "product/src/application/commands/attribute/postgres"
+	postgres_brand_command_handler "product/src/application/commands/brand/postgres"
+	postgres_category_command_handler "product/src/application/commands/category/postgres"
+	postgres_price_list_command_handler "product/src/application/commands/pricelist/postgres"
 	mongo_product_command_handler "product/src/application/commands/product/mongo"
 	postgres_product_command_handler "product/src/application/commands/product/postgres"
+	postgres_review_command_handler "product/src/application/commands/review/postgres"
 	mongo_store_command_handler "product/src/application/commands/store/mongo"
 	postgres_store_command_handler "product/src/application/commands/store/postgres"
 
@@ -52,7 +58,10 @@ import (
 	mongo_store_events_handler "product/src/application/events/store/mongo"
 	postgres_store_events_handler "product/src/application/events/store/postgres"
 
+	exportProduct "product/src/export"
+	"product/src/reservation"
 	seedProduct "product/src/seed"
+	"product/src/settings"
 
 	common_consul "github.com/JohnSalazar/microservices-go-common/consul"
 	consul "github.com/hashicorp/consul/api"
@@ -69,6 +78,9 @@ type Main struct {
 	postgresDatabase    *sql.DB
 	redisDatabase       *redis.Client
 	productReloadCache  *tasks.ProductReloadCacheTask
+	productPromotion    *tasks.ProductPromotionTask
+	productPublish      *tasks.ProductPublishTask
+	productFeed         *tasks.ProductFeedTask
 	httpServer          httputil.HttpServer
 	consulClient        *consul.Client
 	serviceID           string
@@ -85,6 +97,9 @@ func NewMain(
 	postgresDatabase *sql.DB,
 	redisDatabase *redis.Client,
 	productReloadCache *tasks.ProductReloadCacheTask,
+	productPromotion *tasks.ProductPromotionTask,
+	productPublish *tasks.ProductPublishTask,
+	productFeed *tasks.ProductFeedTask,
 	httpServer httputil.HttpServer,
 	consulClient *consul.Client,
 	serviceID string,
@@ -100,6 +115,9 @@ func NewMain(
 		postgresDatabase:    postgresDatabase,
 		redisDatabase:       redisDatabase,
 		productReloadCache:  productReloadCache,
+		productPromotion:    productPromotion,
+		productPublish:      productPublish,
+		productFeed:         productFeed,
 		httpServer:          httpServer,
 		consulClient:        consulClient,
 		serviceID:           serviceID,
@@ -111,6 +129,9 @@ var disableTrace *bool
 var runMigrations *bool
 var disableProductReloadCache *bool
 var seed *bool
+var exportFormat *string
+var exportOutput *string
+var exportDeleted *bool
 
 func main() {
 	production = flag.Bool("prod", false, "use -prod=true to run in production mode")
@@ -118,6 +139,9 @@ func main() {
 	runMigrations = flag.Bool("migrations", false, "use migrations=true if you want to run migrations")
 	disableProductReloadCache = flag.Bool("disable-product-reload-cache", false, "use disable-product-reload-cache=true if you want to disable product reload cache")
 	seed = flag.Bool("seed", false, "use seed=true if you want to enable product recharge")
+	exportFormat = flag.String("export", "", "use export=csv|json|ndjson if you want to dump the catalog and exit")
+	exportOutput = flag.String("export-output", "", "use export-output=path to choose the export file, default products.<format>")
+	exportDeleted = flag.Bool("export-deleted", false, "use export-deleted=true if you want to include deleted products in the export")
 
 	flag.Parse()
 
@@ -141,6 +165,10 @@ func main() {
 	}
 	log.Println("Connected to MongoDB")
 
+	if *runMigrations {
+		migrate.RunMongo(mongo_repository.NewMongoDatabase(app.config.MongoDB.Database, app.client))
+	}
+
 	defer app.natsConn.Close()
 
 	defer app.postgresDatabase.Close()
@@ -187,6 +215,10 @@ func main() {
 		app.productReloadCache.Run()
 	}
 
+	app.productPromotion.Run()
+	app.productPublish.Run()
+	app.productFeed.Run()
+
 	app.httpServer.RunTLSServer()
 
 	<-done
@@ -202,6 +234,10 @@ func main() {
 func startup(ctx context.Context) (*Main, error) {
 	logger := common_log.NewLogger()
 	config := config.LoadConfig(*production, "./config/")
+	settings, err := settings.Load(*production, "./config/")
+	if err != nil {
+		log.Fatal(err)
+	}
 	helpers.CreateFolder(config.Folders)
 	common_validator.NewValidator("en")
 
@@ -290,12 +326,19 @@ func startup(ctx context.Context) (*Main, error) {
 
 	productPostgresRepository := postgres_repository.NewProductRepository(postgresDatabase)
 	storePostgresRepository := postgres_repository.NewStoreRepository(postgresDatabase)
+	reservationPostgresRepository := postgres_repository.NewReservationRepository(postgresDatabase)
+	categoryPostgresRepository := postgres_repository.NewCategoryRepository(postgresDatabase)
+	priceListPostgresRepository := postgres_repository.NewPriceListRepository(postgresDatabase)
+	attributePostgresRepository := postgres_repository.NewAttributeRepository(postgresDatabase)
+	brandPostgresRepository := postgres_repository.NewBrandRepository(postgresDatabase)
+	reviewPostgresRepository := postgres_repository.NewReviewRepository(postgresDatabase)
+	productImportPostgresRepository := postgres_repository.NewProductImportRepository(postgresDatabase)
 
 	redisDatabase := redis_repository.NewRedisClient(config)
 	productRedisRepository := redis_repository.NewProductRepository(redisDatabase)
-	productRepositoryDecorator := decorators.NewProductRepositoryDecorator(productMongoRepository, productPostgresRepository, productRedisRepository, natsPublisher)
+	productRepositoryDecorator := decorators.NewProductRepositoryDecorator(productMongoRepository, productPostgresRepository, productRedisRepository, categoryPostgresRepository, brandPostgresRepository, natsPublisher)
 
-	storeTask := tasks.NewStoreTask(storePostgresRepository, emailService, natsPublisher)
+	storeTask := tasks.NewStoreTask(storePostgresRepository, reservationPostgresRepository, emailService, natsPublisher)
 
 	postgresProductEventsHandler := postgres_product_events_handler.NewProductEventHandler(natsPublisher)
 	mongoProductEventsHandler := mongo_product_events_handler.NewProductEventHandler(productRedisRepository, natsPublisher)
@@ -303,12 +346,23 @@ func startup(ctx context.Context) (*Main, error) {
 	postgresStoreEventsHandler := postgres_store_events_handler.NewStoreEventHandler(storeTask, natsPublisher)
 	mongoStoreEventsHandler := mongo_store_events_handler.NewStoreEventHandler()
 
-	postgresProductCommandHandler := postgres_product_command_handler.NewProductCommandHandler(productPostgresRepository, eventSourcingMongoRepository, postgresProductEventsHandler)
-	mongoProductCommandHandler := mongo_product_command_handler.NewProductCommandHandler(productMongoRepository, mongoProductEventsHandler)
+	postgresProductCommandHandler := postgres_product_command_handler.NewProductCommandHandler(productPostgresRepository, productPostgresRepository, productPostgresRepository, categoryPostgresRepository, priceListPostgresRepository, attributePostgresRepository, brandPostgresRepository, eventSourcingMongoRepository, postgresProductEventsHandler)
+	mongoProductCommandHandler := mongo_product_command_handler.NewProductCommandHandler(productMongoRepository, productMongoRepository, productMongoRepository, mongoProductEventsHandler)
 
-	postgresStoreCommandHandler := postgres_store_command_handler.NewStoreCommandHandler(storePostgresRepository, eventSourcingMongoRepository, postgresStoreEventsHandler, natsPublisher)
+	reservationConfig, err := reservation.LoadConfig(settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	postgresStoreCommandHandler := postgres_store_command_handler.NewStoreCommandHandler(storePostgresRepository, storePostgresRepository, productPostgresRepository, reservationPostgresRepository, eventSourcingMongoRepository, postgresStoreEventsHandler, natsPublisher, reservationConfig)
+	postgresProductImportCommandHandler := postgres_product_command_handler.NewProductImportCommandHandler(productPostgresRepository, productImportPostgresRepository, postgresProductCommandHandler, postgresStoreCommandHandler)
 	mongoStoreCommandHandler := mongo_store_command_handler.NewStoreCommandHandler(storeMongoRepository, mongoStoreEventsHandler)
 
+	postgresCategoryCommandHandler := postgres_category_command_handler.NewCategoryCommandHandler(categoryPostgresRepository, eventSourcingMongoRepository)
+	postgresPriceListCommandHandler := postgres_price_list_command_handler.NewPriceListCommandHandler(priceListPostgresRepository, eventSourcingMongoRepository)
+	postgresAttributeCommandHandler := postgres_attribute_command_handler.NewAttributeCommandHandler(attributePostgresRepository, eventSourcingMongoRepository)
+	postgresBrandCommandHandler := postgres_brand_command_handler.NewBrandCommandHandler(brandPostgresRepository, eventSourcingMongoRepository)
+	postgresReviewCommandHandler := postgres_review_command_handler.NewReviewCommandHandler(reviewPostgresRepository, productPostgresRepository, eventSourcingMongoRepository, natsPublisher)
+
 	securityKeysService := common_services.NewSecurityKeysService(config, certificatesService)
 	managerSecurityKeys := common_security.NewManagerSecurityKeys(config, securityKeysService)
 	managerTokens := common_security.NewManagerTokens(config, managerSecurityKeys)
@@ -329,12 +383,50 @@ func startup(ctx context.Context) (*Main, error) {
 		productMongoRepository,
 		productPostgresRepository,
 		productRedisRepository,
+		priceListPostgresRepository,
+		storePostgresRepository,
 		postgresProductCommandHandler,
 		postgresStoreCommandHandler,
-		natsPublisher,
 	)
-	router := routers.NewRouter(config, metricService, authentication, productController)
+	productImportController := controllers.NewProductImportController(
+		productImportPostgresRepository,
+		productPostgresRepository,
+		postgresProductImportCommandHandler,
+	)
+	reservationController := controllers.NewReservationController(
+		postgresStoreCommandHandler,
+	)
+	categoryController := controllers.NewCategoryController(
+		categoryPostgresRepository,
+		postgresCategoryCommandHandler,
+	)
+	priceListController := controllers.NewPriceListController(
+		priceListPostgresRepository,
+		postgresPriceListCommandHandler,
+	)
+	attributeController := controllers.NewAttributeController(
+		attributePostgresRepository,
+		postgresAttributeCommandHandler,
+	)
+	brandController := controllers.NewBrandController(
+		brandPostgresRepository,
+		postgresBrandCommandHandler,
+	)
+	reviewController := controllers.NewReviewController(
+		reviewPostgresRepository,
+		postgresReviewCommandHandler,
+	)
+	feedConfig, err := feed.LoadConfig(settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	productFeed := feed.NewFeed(feedConfig, productMongoRepository, brandPostgresRepository)
+	feedController := controllers.NewFeedController(productFeed)
+	router := routers.NewRouter(config, metricService, authentication, productController, productImportController, reservationController, categoryController, priceListController, attributeController, brandController, reviewController, feedController)
 	productReloadCache := tasks.NewProductReloadCacheTask(productMongoRepository, productRedisRepository, emailService)
+	productPromotion := tasks.NewProductPromotionTask(productMongoRepository, productRedisRepository, emailService, natsPublisher)
+	productPublish := tasks.NewProductPublishTask(productPostgresRepository, emailService, natsPublisher)
+	productFeedTask := tasks.NewProductFeedTask(productFeed, emailService)
 	httpServer := httputil.NewHttpServer(config, router.RouterSetup(), certificatesService)
 	app := NewMain(
 		config,
@@ -347,6 +439,9 @@ func startup(ctx context.Context) (*Main, error) {
 		postgresDatabase,
 		redisDatabase,
 		productReloadCache,
+		productPromotion,
+		productPublish,
+		productFeedTask,
 		httpServer,
 		consulClient,
 		serviceID,
@@ -356,10 +451,20 @@ func startup(ctx context.Context) (*Main, error) {
 		migrate.Run(config)
 	}
 
+	err = postgresProductImportCommandHandler.FailInterruptedImports(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	if *seed {
 		seedProduct.Run(postgresProductCommandHandler)
 	}
 
+	if len(*exportFormat) > 0 {
+		exportProduct.Run(productPostgresRepository, *exportFormat, *exportDeleted, *exportOutput)
+		os.Exit(0)
+	}
+
 	listens.Listen()
 
 	return app, nil
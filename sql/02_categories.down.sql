@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_categories CASCADE;
+DROP TABLE IF EXISTS categories CASCADE;
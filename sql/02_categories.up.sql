@@ -0,0 +1,25 @@
+CREATE TABLE categories
+(
+    id UUID PRIMARY KEY NOT NULL,
+    parent_id UUID REFERENCES categories(id),
+    name VARCHAR(200) NOT NULL CHECK ( name <> '' ),
+    slug VARCHAR(250) NOT NULL CHECK ( slug <> '' ),
+    description VARCHAR(2000),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false,
+    CONSTRAINT ck_category_parent CHECK ( parent_id <> id )
+);
+
+CREATE UNIQUE INDEX ux_categories_slug ON categories (slug) WHERE deleted = false;
+CREATE INDEX ix_categories_parent_id ON categories (parent_id);
+
+CREATE TABLE product_categories
+(
+    product_id UUID NOT NULL REFERENCES products(id),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX ix_product_categories_category_id ON product_categories (category_id);
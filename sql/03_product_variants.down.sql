@@ -0,0 +1,3 @@
+ALTER TABLE stores DROP COLUMN IF EXISTS "variantid";
+DROP TABLE IF EXISTS product_variants CASCADE;
+ALTER TABLE products DROP COLUMN IF EXISTS options;
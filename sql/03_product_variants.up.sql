@@ -0,0 +1,21 @@
+ALTER TABLE products ADD COLUMN options JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE product_variants
+(
+    id UUID PRIMARY KEY NOT NULL,
+    "productid" UUID NOT NULL REFERENCES products(id),
+    sku VARCHAR(100) NOT NULL CHECK ( sku <> '' ),
+    options JSONB NOT NULL DEFAULT '{}',
+    price numeric(10,2),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX ux_product_variants_sku ON product_variants (sku) WHERE deleted = false;
+CREATE INDEX ix_product_variants_productid ON product_variants ("productid");
+
+ALTER TABLE stores ADD COLUMN "variantid" UUID REFERENCES product_variants(id);
+
+CREATE INDEX ix_stores_variantid ON stores ("variantid");
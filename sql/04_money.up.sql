@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_prices CASCADE;
+DROP TABLE IF EXISTS price_lists CASCADE;
@@ -0,0 +1,24 @@
+CREATE TABLE price_lists
+(
+    id UUID PRIMARY KEY NOT NULL,
+    code VARCHAR(100) NOT NULL CHECK ( code <> '' ),
+    currency CHAR(3) NOT NULL,
+    channel VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX ux_price_lists_code ON price_lists (code) WHERE deleted = false;
+CREATE UNIQUE INDEX ux_price_lists_currency_channel ON price_lists (currency, channel) WHERE deleted = false;
+
+CREATE TABLE product_prices
+(
+    product_id UUID NOT NULL REFERENCES products(id),
+    price_list_id UUID NOT NULL REFERENCES price_lists(id),
+    price numeric(10,2) NOT NULL,
+    PRIMARY KEY (product_id, price_list_id)
+);
+
+CREATE INDEX ix_product_prices_price_list_id ON product_prices (price_list_id);
@@ -0,0 +1,11 @@
+CREATE TABLE product_promotions
+(
+    id UUID PRIMARY KEY NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id),
+    price numeric(10,2) NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL CHECK ( ends_at > starts_at ),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ix_product_promotions_product_id ON product_promotions (product_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_products_status_publish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS status;
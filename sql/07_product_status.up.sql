@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published' CHECK ( status IN ('draft', 'scheduled', 'published', 'archived') );
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS ix_products_status_publish_at ON products (status, publish_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_slug_history CASCADE;
+DROP INDEX IF EXISTS ux_products_slug;
@@ -0,0 +1,20 @@
+UPDATE products SET slug = products.slug || '-' || duplicates.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS position
+    FROM products
+    WHERE deleted = false
+) duplicates
+WHERE duplicates.id = products.id
+AND duplicates.position > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_products_slug ON products (slug) WHERE deleted = false;
+
+DROP TABLE IF EXISTS product_slug_history CASCADE;
+CREATE TABLE product_slug_history
+(
+    slug VARCHAR(600) PRIMARY KEY NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ix_product_slug_history_product_id ON product_slug_history (product_id);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS product_translations CASCADE;
+CREATE TABLE product_translations
+(
+    product_id UUID NOT NULL REFERENCES products(id),
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(500) NOT NULL CHECK ( name <> '' ),
+    slug VARCHAR(600) NOT NULL CHECK ( slug <> '' ),
+    description VARCHAR(10000),
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE INDEX ix_product_translations_slug ON product_translations (slug);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_attributes CASCADE;
+DROP TABLE IF EXISTS attributes CASCADE;
@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS attributes CASCADE;
+CREATE TABLE attributes
+(
+    id UUID PRIMARY KEY NOT NULL,
+    code VARCHAR(100) NOT NULL CHECK ( code <> '' ),
+    name VARCHAR(200) NOT NULL CHECK ( name <> '' ),
+    type VARCHAR(10) NOT NULL CHECK ( type IN ('string', 'number', 'boolean', 'enum') ),
+    "values" JSONB NOT NULL DEFAULT '[]',
+    unit VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX ux_attributes_code ON attributes (code) WHERE deleted = false;
+
+DROP TABLE IF EXISTS product_attributes CASCADE;
+CREATE TABLE product_attributes
+(
+    product_id UUID NOT NULL REFERENCES products(id),
+    attribute_id UUID NOT NULL REFERENCES attributes(id),
+    value JSONB NOT NULL,
+    PRIMARY KEY (product_id, attribute_id)
+);
+
+CREATE INDEX ix_product_attributes_attribute_id ON product_attributes (attribute_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_products_brand_id;
+ALTER TABLE products DROP COLUMN IF EXISTS brand_id;
+DROP TABLE IF EXISTS brands CASCADE;
@@ -0,0 +1,18 @@
+CREATE TABLE brands
+(
+    id UUID PRIMARY KEY NOT NULL,
+    name VARCHAR(200) NOT NULL CHECK ( name <> '' ),
+    slug VARCHAR(250) NOT NULL CHECK ( slug <> '' ),
+    logo VARCHAR(500),
+    description VARCHAR(2000),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX ux_brands_slug ON brands (slug) WHERE deleted = false;
+
+ALTER TABLE products ADD COLUMN brand_id UUID REFERENCES brands(id);
+
+CREATE INDEX ix_products_brand_id ON products (brand_id);
@@ -0,0 +1,13 @@
+ALTER TABLE products ADD COLUMN type VARCHAR(10) NOT NULL DEFAULT 'simple' CHECK ( type IN ('simple', 'bundle') );
+
+CREATE TABLE product_components
+(
+    bundle_id UUID NOT NULL REFERENCES products(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID REFERENCES product_variants(id),
+    quantity integer NOT NULL CHECK ( quantity > 0 ),
+    PRIMARY KEY (bundle_id, product_id),
+    CONSTRAINT ck_product_components_bundle CHECK ( bundle_id <> product_id )
+);
+
+CREATE INDEX ix_product_components_product_id ON product_components (product_id);
@@ -0,0 +1,11 @@
+CREATE TABLE product_relations
+(
+    product_id UUID NOT NULL REFERENCES products(id),
+    related_id UUID NOT NULL REFERENCES products(id),
+    type VARCHAR(20) NOT NULL CHECK ( type IN ('related', 'accessory', 'upsell') ),
+    position integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, type, related_id),
+    CONSTRAINT ck_product_relations_self CHECK ( product_id <> related_id )
+);
+
+CREATE INDEX ix_product_relations_related_id ON product_relations (related_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS ix_products_rating;
+
+ALTER TABLE products DROP COLUMN IF EXISTS review_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating;
+
+DROP TABLE IF EXISTS reviews CASCADE;
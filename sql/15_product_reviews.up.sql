@@ -0,0 +1,22 @@
+CREATE TABLE reviews
+(
+    id UUID PRIMARY KEY NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id),
+    user_id VARCHAR(24) NOT NULL CHECK ( user_id <> '' ),
+    rating smallint NOT NULL CHECK ( rating BETWEEN 1 AND 5 ),
+    title VARCHAR(200),
+    comment VARCHAR(4000),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ( status IN ('pending', 'approved', 'rejected') ),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0,
+    deleted boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX ux_reviews_product_id_user_id ON reviews (product_id, user_id) WHERE deleted = false;
+CREATE INDEX ix_reviews_product_id_status ON reviews (product_id, status);
+
+ALTER TABLE products ADD COLUMN rating NUMERIC(3,2) NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN review_count integer NOT NULL DEFAULT 0;
+
+CREATE INDEX ix_products_rating ON products (rating);
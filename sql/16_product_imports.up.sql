@@ -0,0 +1,13 @@
+CREATE TABLE product_imports
+(
+    id UUID PRIMARY KEY NOT NULL,
+    format VARCHAR(10) NOT NULL CHECK ( format IN ('csv', 'json', 'ndjson') ),
+    status VARCHAR(20) NOT NULL CHECK ( status IN ('running', 'completed', 'failed') ),
+    total integer NOT NULL DEFAULT 0,
+    succeeded integer NOT NULL DEFAULT 0,
+    failed integer NOT NULL DEFAULT 0,
+    report JSONB NOT NULL DEFAULT '[]',
+    created_by VARCHAR(24),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE stores DROP COLUMN IF EXISTS reservation_id;
+
+DROP TABLE IF EXISTS reservations CASCADE;
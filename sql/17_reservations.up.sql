@@ -0,0 +1,19 @@
+CREATE TABLE reservations
+(
+    id UUID PRIMARY KEY NOT NULL,
+    order_id VARCHAR(24) NOT NULL CHECK ( order_id <> '' ),
+    customer_id VARCHAR(24),
+    lines JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL CHECK ( status IN ('active', 'paid', 'released', 'expired') ),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    version integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_reservations_order_id ON reservations (order_id);
+CREATE UNIQUE INDEX idx_reservations_order_id_active ON reservations (order_id) WHERE status = 'active';
+
+ALTER TABLE stores ADD COLUMN reservation_id UUID REFERENCES reservations(id);
+
+CREATE INDEX idx_stores_reservation_id ON stores (reservation_id);
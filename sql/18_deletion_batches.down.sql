@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_stores_deletion_id;
+
+ALTER TABLE stores DROP COLUMN IF EXISTS deletion_id;
+ALTER TABLE products DROP COLUMN IF EXISTS deletion_id;
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deletion_id UUID;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS deletion_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_stores_deletion_id ON stores (deletion_id);
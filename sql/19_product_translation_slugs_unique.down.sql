@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ux_product_translations_slug;
+
+CREATE INDEX IF NOT EXISTS ix_product_translations_slug ON product_translations (slug);
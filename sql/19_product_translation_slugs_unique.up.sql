@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_product_translations_slug;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_product_translations_slug ON product_translations (locale, slug);
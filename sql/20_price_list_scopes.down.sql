@@ -0,0 +1,3 @@
+ALTER TABLE product_promotions DROP COLUMN IF EXISTS price_list_id;
+
+DROP TABLE IF EXISTS product_variant_prices CASCADE;
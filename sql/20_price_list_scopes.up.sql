@@ -0,0 +1,11 @@
+CREATE TABLE product_variant_prices
+(
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    price_list_id UUID NOT NULL REFERENCES price_lists(id),
+    price numeric(10,2) NOT NULL,
+    PRIMARY KEY (variant_id, price_list_id)
+);
+
+CREATE INDEX ix_product_variant_prices_price_list_id ON product_variant_prices (price_list_id);
+
+ALTER TABLE product_promotions ADD COLUMN price_list_id UUID REFERENCES price_lists(id);
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateAttributeCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Values      []string  `json:"values,omitempty"`
+	Unit        string    `json:"unit,omitempty"`
+}
@@ -0,0 +1,194 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	commands "product/src/application/commands/attribute"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/models"
+	"product/src/validators"
+	"time"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type AttributeCommandHandler struct {
+	attributePostgresRepository  repository_interface.AttributeRepository
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository
+}
+
+func NewAttributeCommandHandler(
+	attributePostgresRepository repository_interface.AttributeRepository,
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
+) *AttributeCommandHandler {
+	common_validator.NewValidator("en")
+	return &AttributeCommandHandler{
+		attributePostgresRepository:  attributePostgresRepository,
+		eventSourcingMongoRepository: eventSourcingMongoRepository,
+	}
+}
+
+func (attribute *AttributeCommandHandler) CreateAttributeCommandHandler(ctx context.Context, command *commands.CreateAttributeCommand) (*models.Attribute, error) {
+	attributeDto := &dtos.AddAttribute{
+		ID:     command.ID,
+		Code:   command.Code,
+		Name:   command.Name,
+		Type:   command.Type,
+		Values: command.Values,
+		Unit:   command.Unit,
+	}
+
+	result := validators.ValidateAddAttribute(attributeDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	attributeModel := &models.Attribute{
+		ID:        attributeDto.ID,
+		Code:      attributeDto.Code,
+		Name:      attributeDto.Name,
+		Type:      attributeDto.Type,
+		Values:    attributeDto.Values,
+		Unit:      attributeDto.Unit,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	attributeExists, err := attribute.attributePostgresRepository.FindByCode(ctx, attributeModel.Code)
+	if err != nil {
+		return nil, err
+	}
+	if attributeExists != nil {
+		return nil, errors.New("attribute already exists")
+	}
+
+	attributeModel, err = attribute.attributePostgresRepository.Create(ctx, attributeModel)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute.createEventSourcing(ctx, attributeModel, "attribute.create")
+
+	return attributeModel, nil
+}
+
+func (attribute *AttributeCommandHandler) UpdateAttributeCommandHandler(ctx context.Context, command *commands.UpdateAttributeCommand) (*models.Attribute, error) {
+	attributeDto := &dtos.UpdateAttribute{
+		ID:      command.ID,
+		Code:    command.Code,
+		Name:    command.Name,
+		Type:    command.Type,
+		Values:  command.Values,
+		Unit:    command.Unit,
+		Version: command.Version,
+	}
+
+	result := validators.ValidateUpdateAttribute(attributeDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	current, err := attribute.attributePostgresRepository.FindByID(ctx, attributeDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New("attribute not found")
+	}
+
+	attributeExists, _ := attribute.attributePostgresRepository.FindByCode(ctx, attributeDto.Code)
+	if attributeExists != nil && attributeExists.ID != attributeDto.ID {
+		return nil, errors.New("attribute with this code already exists with another id")
+	}
+
+	if current.Code != attributeDto.Code || current.Type != attributeDto.Type || attribute.removedValues(current.Values, attributeDto.Values) {
+		inUse, err := attribute.attributePostgresRepository.InUse(ctx, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			return nil, errors.New("attribute code, type and enum values cannot be changed while products use it")
+		}
+	}
+
+	attributeModel := &models.Attribute{
+		ID:        attributeDto.ID,
+		Code:      attributeDto.Code,
+		Name:      attributeDto.Name,
+		Type:      attributeDto.Type,
+		Values:    attributeDto.Values,
+		Unit:      attributeDto.Unit,
+		CreatedAt: current.CreatedAt,
+		Version:   attributeDto.Version,
+	}
+
+	attributeModel, err = attribute.attributePostgresRepository.Update(ctx, attributeModel)
+	if err != nil {
+		return nil, err
+	}
+
+	attribute.createEventSourcing(ctx, attributeModel, "attribute.update")
+
+	return attributeModel, nil
+}
+
+func (attribute *AttributeCommandHandler) DeleteAttributeCommandHandler(ctx context.Context, command *commands.DeleteAttributeCommand) error {
+	attributeModel, err := attribute.attributePostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if attributeModel == nil {
+		return errors.New("attribute not found")
+	}
+
+	inUse, err := attribute.attributePostgresRepository.InUse(ctx, attributeModel.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("attribute is used by products")
+	}
+
+	err = attribute.attributePostgresRepository.Delete(ctx, attributeModel.ID)
+	if err != nil {
+		return err
+	}
+
+	attributeModel.Deleted = true
+	attribute.createEventSourcing(ctx, attributeModel, "attribute.delete")
+
+	return nil
+}
+
+func (attribute *AttributeCommandHandler) removedValues(current []string, values []string) bool {
+	kept := map[string]bool{}
+	for _, value := range values {
+		kept[value] = true
+	}
+
+	for _, value := range current {
+		if !kept[value] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (attribute *AttributeCommandHandler) createEventSourcing(ctx context.Context, attributeModel *models.Attribute, messageType string) {
+	data, _ := json.Marshal(attributeModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: attributeModel.ID,
+		MessageType: messageType,
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go attribute.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+}
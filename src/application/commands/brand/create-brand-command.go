@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateBrandCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Logo        string    `json:"logo,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
@@ -0,0 +1,165 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	commands "product/src/application/commands/brand"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/helpers"
+	"product/src/models"
+	"product/src/validators"
+	"time"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type BrandCommandHandler struct {
+	brandPostgresRepository      repository_interface.BrandRepository
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository
+}
+
+func NewBrandCommandHandler(
+	brandPostgresRepository repository_interface.BrandRepository,
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
+) *BrandCommandHandler {
+	common_validator.NewValidator("en")
+	return &BrandCommandHandler{
+		brandPostgresRepository:      brandPostgresRepository,
+		eventSourcingMongoRepository: eventSourcingMongoRepository,
+	}
+}
+
+func (brand *BrandCommandHandler) CreateBrandCommandHandler(ctx context.Context, command *commands.CreateBrandCommand) (*models.Brand, error) {
+	brandDto := &dtos.AddBrand{
+		ID:          command.ID,
+		Name:        command.Name,
+		Slug:        brand.prepareSlug(command.Name, command.Slug),
+		Logo:        command.Logo,
+		Description: command.Description,
+	}
+
+	result := validators.ValidateAddBrand(brandDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	brandModel := &models.Brand{
+		ID:          brandDto.ID,
+		Name:        brandDto.Name,
+		Slug:        brandDto.Slug,
+		Logo:        brandDto.Logo,
+		Description: brandDto.Description,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	brandExists, err := brand.brandPostgresRepository.FindBySlug(ctx, brandModel.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if brandExists != nil {
+		return nil, errors.New("brand already exists")
+	}
+
+	brandModel, err = brand.brandPostgresRepository.Create(ctx, brandModel)
+	if err != nil {
+		return nil, err
+	}
+
+	brand.createEventSourcing(ctx, brandModel, "brand.create")
+
+	return brandModel, nil
+}
+
+func (brand *BrandCommandHandler) UpdateBrandCommandHandler(ctx context.Context, command *commands.UpdateBrandCommand) (*models.Brand, error) {
+	brandDto := &dtos.UpdateBrand{
+		ID:          command.ID,
+		Name:        command.Name,
+		Slug:        brand.prepareSlug(command.Name, command.Slug),
+		Logo:        command.Logo,
+		Description: command.Description,
+		Version:     command.Version,
+	}
+
+	result := validators.ValidateUpdateBrand(brandDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	brandModel := &models.Brand{
+		ID:          brandDto.ID,
+		Name:        brandDto.Name,
+		Slug:        brandDto.Slug,
+		Logo:        brandDto.Logo,
+		Description: brandDto.Description,
+		Version:     brandDto.Version,
+	}
+
+	brandExists, _ := brand.brandPostgresRepository.FindBySlug(ctx, brandModel.Slug)
+	if brandExists != nil && brandExists.ID != brandModel.ID {
+		return nil, errors.New("brand with this slug already exists with another id")
+	}
+
+	brandModel, err := brand.brandPostgresRepository.Update(ctx, brandModel)
+	if err != nil {
+		return nil, err
+	}
+
+	brand.createEventSourcing(ctx, brandModel, "brand.update")
+
+	return brandModel, nil
+}
+
+func (brand *BrandCommandHandler) DeleteBrandCommandHandler(ctx context.Context, command *commands.DeleteBrandCommand) error {
+	brandModel, err := brand.brandPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if brandModel == nil {
+		return errors.New("brand not found")
+	}
+
+	inUse, err := brand.brandPostgresRepository.InUse(ctx, brandModel.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("brand is used by products")
+	}
+
+	err = brand.brandPostgresRepository.Delete(ctx, brandModel.ID)
+	if err != nil {
+		return err
+	}
+
+	brandModel.Deleted = true
+	brand.createEventSourcing(ctx, brandModel, "brand.delete")
+
+	return nil
+}
+
+func (brand *BrandCommandHandler) prepareSlug(name string, slug string) string {
+	if len(strings.TrimSpace(slug)) == 0 {
+		slug = name
+	}
+
+	return helpers.Slugify(slug)
+}
+
+func (brand *BrandCommandHandler) createEventSourcing(ctx context.Context, brandModel *models.Brand, messageType string) {
+	data, _ := json.Marshal(brandModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: brandModel.ID,
+		MessageType: messageType,
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go brand.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+}
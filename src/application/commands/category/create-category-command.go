@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateCategoryCommand struct {
+	AggregateID uuid.UUID     `json:"aggregateId"`
+	MessageType string        `json:"messageType"`
+	Timestamp   time.Time     `json:"timestamp"`
+	ID          uuid.UUID     `json:"id"`
+	ParentID    uuid.NullUUID `json:"parentid"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description,omitempty"`
+}
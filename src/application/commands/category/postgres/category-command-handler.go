@@ -0,0 +1,197 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	commands "product/src/application/commands/category"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/models"
+	"product/src/validators"
+	"time"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type CategoryCommandHandler struct {
+	categoryPostgresRepository   repository_interface.CategoryRepository
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository
+}
+
+func NewCategoryCommandHandler(
+	categoryPostgresRepository repository_interface.CategoryRepository,
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
+) *CategoryCommandHandler {
+	common_validator.NewValidator("en")
+	return &CategoryCommandHandler{
+		categoryPostgresRepository:   categoryPostgresRepository,
+		eventSourcingMongoRepository: eventSourcingMongoRepository,
+	}
+}
+
+func (category *CategoryCommandHandler) CreateCategoryCommandHandler(ctx context.Context, command *commands.CreateCategoryCommand) (*models.Category, error) {
+	categoryDto := &dtos.AddCategory{
+		ID:          command.ID,
+		ParentID:    command.ParentID,
+		Name:        command.Name,
+		Slug:        command.Slug,
+		Description: command.Description,
+	}
+
+	result := validators.ValidateAddCategory(categoryDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	categoryModel := &models.Category{
+		ID:          categoryDto.ID,
+		ParentID:    categoryDto.ParentID,
+		Name:        categoryDto.Name,
+		Slug:        categoryDto.Slug,
+		Description: categoryDto.Description,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	categoryExists, err := category.categoryPostgresRepository.FindBySlug(ctx, categoryModel.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if categoryExists != nil {
+		return nil, errors.New("category already exists")
+	}
+
+	err = category.checkParent(ctx, categoryModel)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryModel, err = category.categoryPostgresRepository.Create(ctx, categoryModel)
+	if err != nil {
+		return nil, err
+	}
+
+	category.createEventSourcing(ctx, categoryModel, "category.create")
+
+	return categoryModel, nil
+}
+
+func (category *CategoryCommandHandler) UpdateCategoryCommandHandler(ctx context.Context, command *commands.UpdateCategoryCommand) (*models.Category, error) {
+	categoryDto := &dtos.UpdateCategory{
+		ID:          command.ID,
+		ParentID:    command.ParentID,
+		Name:        command.Name,
+		Slug:        command.Slug,
+		Description: command.Description,
+		Version:     command.Version,
+	}
+
+	result := validators.ValidateUpdateCategory(categoryDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	categoryModel := &models.Category{
+		ID:          categoryDto.ID,
+		ParentID:    categoryDto.ParentID,
+		Name:        categoryDto.Name,
+		Slug:        categoryDto.Slug,
+		Description: categoryDto.Description,
+		Version:     categoryDto.Version,
+	}
+
+	categoryExists, _ := category.categoryPostgresRepository.FindBySlug(ctx, categoryModel.Slug)
+	if categoryExists != nil && categoryExists.ID != categoryModel.ID {
+		return nil, errors.New("category with this slug already exists with another id")
+	}
+
+	err := category.checkParent(ctx, categoryModel)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryModel, err = category.categoryPostgresRepository.Update(ctx, categoryModel)
+	if err != nil {
+		return nil, err
+	}
+
+	category.createEventSourcing(ctx, categoryModel, "category.update")
+
+	return categoryModel, nil
+}
+
+func (category *CategoryCommandHandler) DeleteCategoryCommandHandler(ctx context.Context, command *commands.DeleteCategoryCommand) error {
+	categoryModel, err := category.categoryPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if categoryModel == nil {
+		return errors.New("category not found")
+	}
+
+	children, err := category.categoryPostgresRepository.GetChildren(ctx, categoryModel.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return errors.New("category has subcategories")
+	}
+
+	err = category.categoryPostgresRepository.Delete(ctx, categoryModel.ID)
+	if err != nil {
+		return err
+	}
+
+	categoryModel.Deleted = true
+	category.createEventSourcing(ctx, categoryModel, "category.delete")
+
+	return nil
+}
+
+func (category *CategoryCommandHandler) checkParent(ctx context.Context, categoryModel *models.Category) error {
+	if !categoryModel.ParentID.Valid {
+		return nil
+	}
+
+	if categoryModel.ParentID.UUID == categoryModel.ID {
+		return errors.New("category cannot be its own parent")
+	}
+
+	parent, err := category.categoryPostgresRepository.FindByID(ctx, categoryModel.ParentID.UUID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return errors.New("parent category not found")
+	}
+
+	descendants, err := category.categoryPostgresRepository.GetDescendantIDs(ctx, categoryModel.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, descendant := range descendants {
+		if descendant == parent.ID {
+			return errors.New("parent category cannot be a subcategory of this category")
+		}
+	}
+
+	return nil
+}
+
+func (category *CategoryCommandHandler) createEventSourcing(ctx context.Context, categoryModel *models.Category, messageType string) {
+	data, _ := json.Marshal(categoryModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: categoryModel.ID,
+		MessageType: messageType,
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go category.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+}
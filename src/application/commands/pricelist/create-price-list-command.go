@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePriceListCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Currency    string    `json:"currency"`
+	Channel     string    `json:"channel,omitempty"`
+}
@@ -0,0 +1,188 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	commands "product/src/application/commands/pricelist"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/models"
+	"product/src/validators"
+	"time"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type PriceListCommandHandler struct {
+	priceListPostgresRepository  repository_interface.PriceListRepository
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository
+}
+
+func NewPriceListCommandHandler(
+	priceListPostgresRepository repository_interface.PriceListRepository,
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
+) *PriceListCommandHandler {
+	common_validator.NewValidator("en")
+	return &PriceListCommandHandler{
+		priceListPostgresRepository:  priceListPostgresRepository,
+		eventSourcingMongoRepository: eventSourcingMongoRepository,
+	}
+}
+
+func (priceList *PriceListCommandHandler) CreatePriceListCommandHandler(ctx context.Context, command *commands.CreatePriceListCommand) (*models.PriceList, error) {
+	priceListDto := &dtos.AddPriceList{
+		ID:       command.ID,
+		Code:     command.Code,
+		Currency: command.Currency,
+		Channel:  command.Channel,
+	}
+
+	result := validators.ValidateAddPriceList(priceListDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	priceListModel := &models.PriceList{
+		ID:        priceListDto.ID,
+		Code:      priceListDto.Code,
+		Currency:  priceListDto.Currency,
+		Channel:   priceListDto.Channel,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := priceList.checkUnique(ctx, priceListModel)
+	if err != nil {
+		return nil, err
+	}
+
+	priceListModel, err = priceList.priceListPostgresRepository.Create(ctx, priceListModel)
+	if err != nil {
+		return nil, err
+	}
+
+	priceList.createEventSourcing(ctx, priceListModel, "pricelist.create")
+
+	return priceListModel, nil
+}
+
+func (priceList *PriceListCommandHandler) UpdatePriceListCommandHandler(ctx context.Context, command *commands.UpdatePriceListCommand) (*models.PriceList, error) {
+	priceListDto := &dtos.UpdatePriceList{
+		ID:       command.ID,
+		Code:     command.Code,
+		Currency: command.Currency,
+		Channel:  command.Channel,
+		Version:  command.Version,
+	}
+
+	result := validators.ValidateUpdatePriceList(priceListDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	priceListExists, err := priceList.priceListPostgresRepository.FindByID(ctx, priceListDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if priceListExists == nil {
+		return nil, errors.New("price list not found")
+	}
+
+	priceListModel := &models.PriceList{
+		ID:        priceListDto.ID,
+		Code:      priceListDto.Code,
+		Currency:  priceListDto.Currency,
+		Channel:   priceListDto.Channel,
+		CreatedAt: priceListExists.CreatedAt,
+		Version:   priceListDto.Version,
+	}
+
+	if priceListModel.Currency != priceListExists.Currency {
+		inUse, err := priceList.priceListPostgresRepository.InUse(ctx, priceListModel.ID)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			return nil, errors.New("price list currency cannot be changed while products use it")
+		}
+	}
+
+	err = priceList.checkUnique(ctx, priceListModel)
+	if err != nil {
+		return nil, err
+	}
+
+	priceListModel, err = priceList.priceListPostgresRepository.Update(ctx, priceListModel)
+	if err != nil {
+		return nil, err
+	}
+
+	priceList.createEventSourcing(ctx, priceListModel, "pricelist.update")
+
+	return priceListModel, nil
+}
+
+func (priceList *PriceListCommandHandler) DeletePriceListCommandHandler(ctx context.Context, command *commands.DeletePriceListCommand) error {
+	priceListModel, err := priceList.priceListPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if priceListModel == nil {
+		return errors.New("price list not found")
+	}
+
+	inUse, err := priceList.priceListPostgresRepository.InUse(ctx, priceListModel.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("price list is used by products")
+	}
+
+	err = priceList.priceListPostgresRepository.Delete(ctx, priceListModel.ID)
+	if err != nil {
+		return err
+	}
+
+	priceListModel.Deleted = true
+	priceList.createEventSourcing(ctx, priceListModel, "pricelist.delete")
+
+	return nil
+}
+
+func (priceList *PriceListCommandHandler) checkUnique(ctx context.Context, priceListModel *models.PriceList) error {
+	priceListExists, err := priceList.priceListPostgresRepository.FindByCode(ctx, priceListModel.Code)
+	if err != nil {
+		return err
+	}
+	if priceListExists != nil && priceListExists.ID != priceListModel.ID {
+		return errors.New("price list with this code already exists")
+	}
+
+	priceListExists, err = priceList.priceListPostgresRepository.FindByCurrency(ctx, priceListModel.Currency, priceListModel.Channel)
+	if err != nil {
+		return err
+	}
+	if priceListExists != nil && priceListExists.ID != priceListModel.ID && priceListExists.Channel == priceListModel.Channel {
+		return errors.New("price list for this currency and channel already exists")
+	}
+
+	return nil
+}
+
+func (priceList *PriceListCommandHandler) createEventSourcing(ctx context.Context, priceListModel *models.PriceList, messageType string) {
+	data, _ := json.Marshal(priceListModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: priceListModel.ID,
+		MessageType: messageType,
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go priceList.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+}
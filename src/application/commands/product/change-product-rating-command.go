@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeProductRatingCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Rating      float64   `json:"rating"`
+	ReviewCount uint      `json:"review_count"`
+}
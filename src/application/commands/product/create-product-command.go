@@ -1,24 +1,40 @@
 package commands
 
 import (
+	"product/src/models"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type CreateProductCommand struct {
-	AggregateID uuid.UUID `json:"aggregateId"`
-	MessageType string    `json:"messageType"`
-	Timestamp   time.Time `json:"timestamp"`
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description,omitempty"`
-	Price       float32   `json:"price"`
-	Quantity    uint      `json:"quantity"`
-	Image       string    `json:"image,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at,omitempty"`
-	Version     uint      `json:"version"`
-	Deleted     bool      `json:"deleted,omitempty"`
+	AggregateID  uuid.UUID                    `json:"aggregateId"`
+	MessageType  string                       `json:"messageType"`
+	Timestamp    time.Time                    `json:"timestamp"`
+	ID           uuid.UUID                    `json:"id"`
+	Name         string                       `json:"name"`
+	Slug         string                       `json:"slug"`
+	Description  string                       `json:"description,omitempty"`
+	Price        models.Money                 `json:"price"`
+	Quantity     uint                         `json:"quantity"`
+	Image        string                       `json:"image,omitempty"`
+	Categories   []uuid.UUID                  `json:"categories,omitempty"`
+	BrandID      uuid.NullUUID                `json:"brandid"`
+	Options      []*models.ProductOption      `json:"options,omitempty"`
+	Variants     []*models.ProductVariant     `json:"variants,omitempty"`
+	Prices       []*models.ProductPrice       `json:"prices,omitempty"`
+	Promotions   []*models.ProductPromotion   `json:"promotions,omitempty"`
+	Status       string                       `json:"status"`
+	PublishAt    time.Time                    `json:"publish_at,omitempty"`
+	Translations []*models.ProductTranslation `json:"translations,omitempty"`
+	Attributes   []*models.ProductAttribute   `json:"attributes,omitempty"`
+	Type         string                       `json:"type"`
+	Components   []*models.ProductComponent   `json:"components,omitempty"`
+	Relations    []*models.ProductRelation    `json:"relations,omitempty"`
+	Rating       float64                      `json:"rating"`
+	ReviewCount  uint                         `json:"review_count"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	UpdatedAt    time.Time                    `json:"updated_at,omitempty"`
+	Version      uint                         `json:"version"`
+	Deleted      bool                         `json:"deleted,omitempty"`
 }
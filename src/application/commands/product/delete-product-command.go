@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeleteProductCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportProductsCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Format      string    `json:"format"`
+	Data        []byte    `json:"-"`
+	CreatedBy   string    `json:"created_by"`
+}
@@ -2,6 +2,7 @@ package mongo_command
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	mongo_event_handler "product/src/application/events/product/mongo"
 	"product/src/data/repositories/interfaces"
 	"product/src/dtos"
+	"product/src/helpers"
 	"product/src/models"
 	"product/src/validators"
 	"time"
@@ -19,29 +21,48 @@ import (
 )
 
 type ProductCommandHandler struct {
-	productMongoRepository interfaces.ProductRepository
-	mongoEventHandler      *mongo_event_handler.ProductEventHandler
+	productMongoRepository       interfaces.ProductRepository
+	productRatingMongoRepository interfaces.ProductRatingRepository
+	productPatchMongoRepository  interfaces.ProductPatchRepository
+	mongoEventHandler            *mongo_event_handler.ProductEventHandler
 }
 
 func NewProductCommandHandler(
 	productMongoRepository interfaces.ProductRepository,
+	productRatingMongoRepository interfaces.ProductRatingRepository,
+	productPatchMongoRepository interfaces.ProductPatchRepository,
 	mongoEventHandler *mongo_event_handler.ProductEventHandler,
 ) *ProductCommandHandler {
 	common_validator.NewValidator("en")
 	return &ProductCommandHandler{
-		productMongoRepository: productMongoRepository,
-		mongoEventHandler:      mongoEventHandler,
+		productMongoRepository:       productMongoRepository,
+		productRatingMongoRepository: productRatingMongoRepository,
+		productPatchMongoRepository:  productPatchMongoRepository,
+		mongoEventHandler:            mongoEventHandler,
 	}
 }
 
 func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Context, command *commands.CreateProductCommand) error {
 	productDto := &dtos.AddProduct{
-		Name:        command.Name,
-		Slug:        command.Slug,
-		Description: command.Description,
-		Price:       command.Price,
-		Quantity:    command.Quantity,
-		Image:       command.Image,
+		Name:         command.Name,
+		Slug:         command.Slug,
+		Description:  command.Description,
+		Price:        command.Price,
+		Quantity:     command.Quantity,
+		Image:        command.Image,
+		Categories:   command.Categories,
+		BrandID:      command.BrandID,
+		Options:      command.Options,
+		Variants:     command.Variants,
+		Prices:       command.Prices,
+		Promotions:   command.Promotions,
+		Status:       command.Status,
+		PublishAt:    command.PublishAt,
+		Translations: command.Translations,
+		Attributes:   command.Attributes,
+		Type:         command.Type,
+		Components:   command.Components,
+		Relations:    command.Relations,
 	}
 
 	result := validators.ValidateAddProduct(productDto)
@@ -50,17 +71,32 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 	}
 
 	productModel := &models.Product{
-		ID:          command.ID,
-		Name:        productDto.Name,
-		Slug:        productDto.Slug,
-		Description: productDto.Description,
-		Price:       productDto.Price,
-		Quantity:    productDto.Quantity,
-		Image:       productDto.Image,
-		CreatedAt:   command.CreatedAt,
-		UpdatedAt:   command.UpdatedAt,
-		Version:     command.Version,
-		Deleted:     command.Deleted,
+		ID:           command.ID,
+		Name:         productDto.Name,
+		Slug:         productDto.Slug,
+		Description:  productDto.Description,
+		Price:        productDto.Price,
+		Quantity:     productDto.Quantity,
+		Image:        productDto.Image,
+		Categories:   productDto.Categories,
+		BrandID:      productDto.BrandID,
+		Options:      productDto.Options,
+		Variants:     productDto.Variants,
+		Prices:       productDto.Prices,
+		Promotions:   productDto.Promotions,
+		Status:       productDto.Status,
+		PublishAt:    productDto.PublishAt,
+		Translations: productDto.Translations,
+		Attributes:   productDto.Attributes,
+		Type:         productDto.Type,
+		Components:   productDto.Components,
+		Relations:    productDto.Relations,
+		Rating:       command.Rating,
+		ReviewCount:  command.ReviewCount,
+		CreatedAt:    command.CreatedAt,
+		UpdatedAt:    command.UpdatedAt,
+		Version:      command.Version,
+		Deleted:      command.Deleted,
 	}
 
 	productExists, _ := product.productMongoRepository.FindByName(ctx, productModel.Name)
@@ -79,20 +115,35 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 	}
 
 	productEvent := &events.ProductCreatedEvent{
-		AggregateID: productModel.ID,
-		MessageType: "product.create",
-		Timestamp:   time.Now().UTC(),
-		ID:          productModel.ID,
-		Name:        productModel.Name,
-		Slug:        productModel.Slug,
-		Description: productModel.Description,
-		Price:       productModel.Price,
-		Quantity:    productModel.Quantity,
-		Image:       productModel.Image,
-		CreatedAt:   productModel.CreatedAt,
-		UpdatedAt:   productModel.UpdatedAt,
-		Version:     productModel.Version,
-		Deleted:     productModel.Deleted,
+		AggregateID:  productModel.ID,
+		MessageType:  "product.create",
+		Timestamp:    time.Now().UTC(),
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Rating:       productModel.Rating,
+		ReviewCount:  productModel.ReviewCount,
+		CreatedAt:    productModel.CreatedAt,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
+		Deleted:      productModel.Deleted,
 	}
 
 	go product.mongoEventHandler.ProductCreatedEventHandler(productEvent)
@@ -102,13 +153,26 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 
 func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Context, command *commands.UpdateProductCommand) error {
 	productDto := &dtos.UpdateProduct{
-		ID:          command.ID,
-		Name:        command.Name,
-		Slug:        command.Slug,
-		Description: command.Description,
-		Price:       command.Price,
-		Image:       command.Image,
-		Version:     command.Version,
+		ID:           command.ID,
+		Name:         command.Name,
+		Slug:         command.Slug,
+		Description:  command.Description,
+		Price:        command.Price,
+		Image:        command.Image,
+		Categories:   command.Categories,
+		BrandID:      command.BrandID,
+		Options:      command.Options,
+		Variants:     command.Variants,
+		Prices:       command.Prices,
+		Promotions:   command.Promotions,
+		Status:       command.Status,
+		PublishAt:    command.PublishAt,
+		Translations: command.Translations,
+		Attributes:   command.Attributes,
+		Type:         command.Type,
+		Components:   command.Components,
+		Relations:    command.Relations,
+		Version:      command.Version,
 	}
 
 	result := validators.ValidateUpdateProduct(productDto)
@@ -117,13 +181,26 @@ func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Co
 	}
 
 	productModel := &models.Product{
-		ID:          productDto.ID,
-		Name:        productDto.Name,
-		Slug:        productDto.Slug,
-		Description: productDto.Description,
-		Price:       productDto.Price,
-		Image:       productDto.Image,
-		Version:     productDto.Version,
+		ID:           productDto.ID,
+		Name:         productDto.Name,
+		Slug:         productDto.Slug,
+		Description:  productDto.Description,
+		Price:        productDto.Price,
+		Image:        productDto.Image,
+		Categories:   productDto.Categories,
+		BrandID:      productDto.BrandID,
+		Options:      productDto.Options,
+		Variants:     productDto.Variants,
+		Prices:       productDto.Prices,
+		Promotions:   productDto.Promotions,
+		Status:       productDto.Status,
+		PublishAt:    productDto.PublishAt,
+		Translations: productDto.Translations,
+		Attributes:   productDto.Attributes,
+		Type:         productDto.Type,
+		Components:   productDto.Components,
+		Relations:    productDto.Relations,
+		Version:      productDto.Version,
 	}
 
 	productMongoExists, _ := product.productMongoRepository.FindByName(ctx, productModel.Name)
@@ -131,23 +208,209 @@ func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Co
 		return errors.New("product with this name already exists with another id")
 	}
 
-	_, err := product.productMongoRepository.Update(ctx, productModel)
+	productMongo, err := product.productMongoRepository.Update(ctx, productModel)
 	if err != nil {
 		return err
 	}
 
 	productEvent := &events.ProductUpdatedEvent{
-		AggregateID: productModel.ID,
-		MessageType: "product.update",
+		AggregateID:  productModel.ID,
+		MessageType:  "product.update",
+		Timestamp:    time.Now().UTC(),
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Rating:       productMongo.Rating,
+		ReviewCount:  productMongo.ReviewCount,
+		Version:      productModel.Version,
+	}
+
+	go product.mongoEventHandler.ProductUpdatedEventHandler(productEvent)
+
+	return nil
+}
+
+// PatchProductCommandHandler applies the changes Postgres already validated, writing only the patched fields so
+// a stale read model cannot overwrite the rest of the product.
+func (product *ProductCommandHandler) PatchProductCommandHandler(ctx context.Context, command *commands.PatchProductCommand) error {
+	var patch map[string]interface{}
+	err := json.Unmarshal(command.Patch, &patch)
+	if err != nil || patch == nil {
+		return helpers.ErrInvalidMergePatch
+	}
+
+	if name, ok := patch["name"].(string); ok {
+		productMongoExists, _ := product.productMongoRepository.FindByName(ctx, name)
+		if productMongoExists != nil && productMongoExists.ID != command.ID {
+			return errors.New("product with this name already exists with another id")
+		}
+	}
+
+	productModel, err := product.productPatchMongoRepository.Patch(ctx, command.ID, patch)
+	if err != nil {
+		return err
+	}
+	if productModel == nil {
+		return errors.New("product not found")
+	}
+
+	productEvent := &events.ProductUpdatedEvent{
+		AggregateID:  productModel.ID,
+		MessageType:  command.MessageType,
+		Timestamp:    time.Now().UTC(),
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Rating:       productModel.Rating,
+		ReviewCount:  productModel.ReviewCount,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
+	}
+
+	go product.mongoEventHandler.ProductUpdatedEventHandler(productEvent)
+
+	return nil
+}
+
+func (product *ProductCommandHandler) DeleteProductCommandHandler(ctx context.Context, command *commands.DeleteProductCommand) error {
+	err := product.productMongoRepository.Delete(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+
+	productEvent := &events.ProductDeletedEvent{
+		AggregateID: command.ID,
+		MessageType: "product.delete",
 		Timestamp:   time.Now().UTC(),
-		ID:          productModel.ID,
-		Name:        productModel.Name,
-		Slug:        productModel.Slug,
-		Description: productModel.Description,
-		Price:       productModel.Price,
-		Quantity:    productModel.Quantity,
-		Image:       productModel.Image,
-		Version:     productModel.Version,
+		ID:          command.ID,
+	}
+
+	go product.mongoEventHandler.ProductDeletedEventHandler(productEvent)
+
+	return nil
+}
+
+func (product *ProductCommandHandler) RestoreProductCommandHandler(ctx context.Context, command *commands.RestoreProductCommand) error {
+	productModel, err := product.productMongoRepository.Restore(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if productModel == nil {
+		return errors.New("product not found")
+	}
+
+	productEvent := &events.ProductCreatedEvent{
+		AggregateID:  productModel.ID,
+		MessageType:  "product.restore",
+		Timestamp:    time.Now().UTC(),
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Rating:       productModel.Rating,
+		ReviewCount:  productModel.ReviewCount,
+		CreatedAt:    productModel.CreatedAt,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
+		Deleted:      productModel.Deleted,
+	}
+
+	go product.mongoEventHandler.ProductCreatedEventHandler(productEvent)
+
+	return nil
+}
+
+func (product *ProductCommandHandler) ChangeProductRatingCommandHandler(ctx context.Context, command *commands.ChangeProductRatingCommand) error {
+	productModel, err := product.productRatingMongoRepository.SetRating(ctx, &models.ProductRating{
+		ProductID:   command.ID,
+		Rating:      command.Rating,
+		ReviewCount: command.ReviewCount,
+	})
+	if err != nil {
+		return err
+	}
+	if productModel == nil {
+		return errors.New("product not found")
+	}
+
+	productEvent := &events.ProductUpdatedEvent{
+		AggregateID:  productModel.ID,
+		MessageType:  command.MessageType,
+		Timestamp:    time.Now().UTC(),
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Rating:       productModel.Rating,
+		ReviewCount:  productModel.ReviewCount,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
 	}
 
 	go product.mongoEventHandler.ProductUpdatedEventHandler(productEvent)
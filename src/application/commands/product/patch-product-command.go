@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PatchProductCommand struct {
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	MessageType string          `json:"messageType"`
+	Timestamp   time.Time       `json:"timestamp"`
+	ID          uuid.UUID       `json:"id"`
+	Patch       json.RawMessage `json:"patch"`
+	Version     uint            `json:"version,omitempty"`
+}
@@ -3,6 +3,7 @@ package postgres_command
 import (
 	"context"
 	"errors"
+	"fmt"
 	commands "product/src/application/commands/product"
 	events "product/src/application/events/product"
 	postgres_event_handler "product/src/application/events/product/postgres"
@@ -11,6 +12,7 @@ import (
 	repository_interface "product/src/data/repositories/interfaces"
 
 	"product/src/dtos"
+	"product/src/helpers"
 	"product/src/models"
 	"product/src/validators"
 	"time"
@@ -20,20 +22,45 @@ import (
 	"github.com/google/uuid"
 )
 
+var productTransitions = map[string][]string{
+	models.ProductDraft:     {models.ProductScheduled, models.ProductPublished, models.ProductArchived},
+	models.ProductScheduled: {models.ProductDraft, models.ProductPublished, models.ProductArchived},
+	models.ProductPublished: {models.ProductDraft, models.ProductArchived},
+	models.ProductArchived:  {models.ProductDraft},
+}
+
 type ProductCommandHandler struct {
 	productPostgresRepository    repository_interface.ProductRepository
+	productStatusRepository      repository_interface.ProductStatusRepository
+	productRelationRepository    repository_interface.ProductRelationRepository
+	categoryPostgresRepository   repository_interface.CategoryRepository
+	priceListPostgresRepository  repository_interface.PriceListRepository
+	attributePostgresRepository  repository_interface.AttributeRepository
+	brandPostgresRepository      repository_interface.BrandRepository
 	eventSourcingMongoRepository repository_interface.EventSourcingRepository
 	postgresEventHandler         *postgres_event_handler.ProductEventHandler
 }
 
 func NewProductCommandHandler(
 	productPostgresRepository repository_interface.ProductRepository,
+	productStatusRepository repository_interface.ProductStatusRepository,
+	productRelationRepository repository_interface.ProductRelationRepository,
+	categoryPostgresRepository repository_interface.CategoryRepository,
+	priceListPostgresRepository repository_interface.PriceListRepository,
+	attributePostgresRepository repository_interface.AttributeRepository,
+	brandPostgresRepository repository_interface.BrandRepository,
 	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
 	postgresEventHandler *postgres_event_handler.ProductEventHandler,
 ) *ProductCommandHandler {
 	common_validator.NewValidator("en")
 	return &ProductCommandHandler{
 		productPostgresRepository:    productPostgresRepository,
+		productStatusRepository:      productStatusRepository,
+		productRelationRepository:    productRelationRepository,
+		categoryPostgresRepository:   categoryPostgresRepository,
+		priceListPostgresRepository:  priceListPostgresRepository,
+		attributePostgresRepository:  attributePostgresRepository,
+		brandPostgresRepository:      brandPostgresRepository,
 		eventSourcingMongoRepository: eventSourcingMongoRepository,
 		postgresEventHandler:         postgresEventHandler,
 	}
@@ -41,13 +68,59 @@ func NewProductCommandHandler(
 
 func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Context, command *commands.CreateProductCommand) (*models.Product, error) {
 	productDto := &dtos.AddProduct{
-		ID:          command.ID,
-		Name:        command.Name,
-		Slug:        command.Slug,
-		Description: command.Description,
-		Price:       command.Price,
-		Quantity:    command.Quantity,
-		Image:       command.Image,
+		ID:           command.ID,
+		Name:         command.Name,
+		Slug:         command.Slug,
+		Description:  command.Description,
+		Price:        command.Price,
+		Quantity:     command.Quantity,
+		Image:        command.Image,
+		Categories:   command.Categories,
+		BrandID:      command.BrandID,
+		Options:      command.Options,
+		Variants:     command.Variants,
+		Prices:       command.Prices,
+		Promotions:   command.Promotions,
+		Status:       command.Status,
+		PublishAt:    command.PublishAt,
+		Translations: command.Translations,
+		Attributes:   command.Attributes,
+		Type:         command.Type,
+		Components:   command.Components,
+	}
+
+	if len(productDto.Variants) > 0 {
+		productDto.Quantity = product.prepareVariants(productDto.ID, productDto.Variants)
+	}
+
+	product.preparePromotions(productDto.Promotions)
+
+	if len(productDto.Status) == 0 {
+		productDto.Status = models.ProductDraft
+	}
+
+	if len(productDto.Type) == 0 {
+		productDto.Type = models.ProductSimple
+	}
+
+	if productDto.Type == models.ProductBundle {
+		productDto.Quantity = 0
+	}
+
+	slug, err := product.prepareSlug(ctx, productDto.ID, productDto.Name, productDto.Slug)
+	if err != nil {
+		return nil, err
+	}
+	productDto.Slug = slug
+
+	err = product.prepareTranslations(ctx, productDto.ID, productDto.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.prepareAttributes(ctx, productDto.Attributes)
+	if err != nil {
+		return nil, err
 	}
 
 	result := validators.ValidateAddProduct(productDto)
@@ -57,14 +130,26 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 
 	productModel := &models.Product{
 		// ID:          uuid.New(),
-		ID:          productDto.ID,
-		Name:        productDto.Name,
-		Slug:        productDto.Slug,
-		Description: productDto.Description,
-		Price:       productDto.Price,
-		Quantity:    productDto.Quantity,
-		Image:       productDto.Image,
-		CreatedAt:   time.Now().UTC(),
+		ID:           productDto.ID,
+		Name:         productDto.Name,
+		Slug:         productDto.Slug,
+		Description:  productDto.Description,
+		Price:        productDto.Price,
+		Quantity:     productDto.Quantity,
+		Image:        productDto.Image,
+		Categories:   productDto.Categories,
+		BrandID:      productDto.BrandID,
+		Options:      productDto.Options,
+		Variants:     productDto.Variants,
+		Prices:       productDto.Prices,
+		Promotions:   productDto.Promotions,
+		Status:       productDto.Status,
+		PublishAt:    productDto.PublishAt,
+		Translations: productDto.Translations,
+		Attributes:   productDto.Attributes,
+		Type:         productDto.Type,
+		Components:   productDto.Components,
+		CreatedAt:    time.Now().UTC(),
 	}
 
 	productPostgresExists, err := product.productPostgresRepository.FindByName(ctx, productModel.Name)
@@ -75,6 +160,31 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 		return nil, errors.New("product already exists")
 	}
 
+	err = product.checkStatus(productModel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkCategories(ctx, productModel.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkBrand(ctx, productModel.BrandID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkComponents(ctx, productModel.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkProductPrices(ctx, productModel)
+	if err != nil {
+		return nil, err
+	}
+
 	productModel, err = product.productPostgresRepository.Create(ctx, productModel)
 	if err != nil {
 		return nil, err
@@ -92,20 +202,33 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 	go product.eventSourcingMongoRepository.Create(ctx, eventSourcing)
 
 	productEvent := &events.ProductCreatedEvent{
-		AggregateID: productModel.ID,
-		MessageType: eventSourcing.MessageType,
-		Timestamp:   eventSourcing.Timestamp,
-		ID:          productModel.ID,
-		Name:        productModel.Name,
-		Slug:        productModel.Slug,
-		Description: productModel.Description,
-		Price:       productModel.Price,
-		Quantity:    productModel.Quantity,
-		Image:       productModel.Image,
-		CreatedAt:   productModel.CreatedAt,
-		UpdatedAt:   productModel.UpdatedAt,
-		Version:     productModel.Version,
-		Deleted:     productModel.Deleted,
+		AggregateID:  productModel.ID,
+		MessageType:  eventSourcing.MessageType,
+		Timestamp:    eventSourcing.Timestamp,
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		CreatedAt:    productModel.CreatedAt,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
+		Deleted:      productModel.Deleted,
 	}
 
 	go product.postgresEventHandler.ProductCreatedEventHandler(ctx, productEvent)
@@ -114,14 +237,234 @@ func (product *ProductCommandHandler) CreateProductCommandHandler(ctx context.Co
 }
 
 func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Context, command *commands.UpdateProductCommand) (*models.Product, error) {
+	productModel, err := product.update(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(productModel)
+
+	storeEvent := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: productModel.ID,
+		MessageType: "product.update",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go product.eventSourcingMongoRepository.Create(ctx, storeEvent)
+
+	product.productUpdated(ctx, productModel, storeEvent)
+
+	return productModel, nil
+}
+
+func (product *ProductCommandHandler) PatchProductCommandHandler(ctx context.Context, command *commands.PatchProductCommand) (*models.Product, error) {
+	var patch map[string]interface{}
+	err := json.Unmarshal(command.Patch, &patch)
+	if err != nil || patch == nil {
+		return nil, helpers.ErrInvalidMergePatch
+	}
+
+	productPostgresCurrent, err := product.productPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return nil, err
+	}
+	if productPostgresCurrent == nil {
+		return nil, models.NewNotFoundError("product not found")
+	}
+
+	for _, variant := range productPostgresCurrent.Variants {
+		variant.Quantity = 0
+	}
+
+	original, err := json.Marshal(product.updateCommand(productPostgresCurrent))
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := helpers.MergePatch(original, command.Patch)
+	if err != nil {
+		return nil, err
+	}
+
+	updateProductCommand := &commands.UpdateProductCommand{}
+	err = json.Unmarshal(merged, updateProductCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateProductCommand.ID != command.ID {
+		return nil, errors.New("Error divergent product id")
+	}
+
+	if command.Version > 0 {
+		updateProductCommand.Version = command.Version
+	}
+
+	productModel, err := product.update(ctx, updateProductCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	modified, err := json.Marshal(product.updateCommand(productModel))
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := helpers.CreateMergePatch(original, modified)
+	if err != nil {
+		return nil, err
+	}
+
+	storeEvent := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: productModel.ID,
+		MessageType: "product.patch",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(changes),
+	}
+	go product.eventSourcingMongoRepository.Create(ctx, storeEvent)
+
+	productEvent := &events.ProductPatchedEvent{
+		AggregateID: productModel.ID,
+		MessageType: storeEvent.MessageType,
+		Timestamp:   storeEvent.Timestamp,
+		ID:          productModel.ID,
+		Patch:       changes,
+	}
+
+	go product.postgresEventHandler.ProductPatchedEventHandler(ctx, productEvent)
+
+	return productModel, nil
+}
+
+// productUpdated hands the product written under eventSourcing to the event handler, which syncs the read model and
+// creates stores for the variant quantities left on productModel.
+func (product *ProductCommandHandler) productUpdated(ctx context.Context, productModel *models.Product, eventSourcing *models.EventSourcing) {
+	productEvent := &events.ProductUpdatedEvent{
+		AggregateID:  productModel.ID,
+		MessageType:  eventSourcing.MessageType,
+		Timestamp:    eventSourcing.Timestamp,
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Quantity:     productModel.Quantity,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		UpdatedAt:    productModel.UpdatedAt,
+		Version:      productModel.Version,
+	}
+
+	go product.postgresEventHandler.ProductUpdatedEventHandler(ctx, productEvent)
+}
+
+func (product *ProductCommandHandler) updateCommand(productModel *models.Product) *commands.UpdateProductCommand {
+	return &commands.UpdateProductCommand{
+		ID:           productModel.ID,
+		Name:         productModel.Name,
+		Slug:         productModel.Slug,
+		Description:  productModel.Description,
+		Price:        productModel.Price,
+		Image:        productModel.Image,
+		Categories:   productModel.Categories,
+		BrandID:      productModel.BrandID,
+		Options:      productModel.Options,
+		Variants:     productModel.Variants,
+		Prices:       productModel.Prices,
+		Promotions:   productModel.Promotions,
+		Status:       productModel.Status,
+		PublishAt:    productModel.PublishAt,
+		Translations: productModel.Translations,
+		Attributes:   productModel.Attributes,
+		Type:         productModel.Type,
+		Components:   productModel.Components,
+		Relations:    productModel.Relations,
+		Version:      productModel.Version,
+	}
+}
+
+func (product *ProductCommandHandler) update(ctx context.Context, command *commands.UpdateProductCommand) (*models.Product, error) {
 	productDto := &dtos.UpdateProduct{
-		ID:          command.ID,
-		Name:        command.Name,
-		Slug:        command.Slug,
-		Description: command.Description,
-		Price:       command.Price,
-		Image:       command.Image,
-		Version:     command.Version,
+		ID:           command.ID,
+		Name:         command.Name,
+		Slug:         command.Slug,
+		Description:  command.Description,
+		Price:        command.Price,
+		Image:        command.Image,
+		Categories:   command.Categories,
+		BrandID:      command.BrandID,
+		Options:      command.Options,
+		Variants:     command.Variants,
+		Prices:       command.Prices,
+		Promotions:   command.Promotions,
+		Status:       command.Status,
+		PublishAt:    command.PublishAt,
+		Translations: command.Translations,
+		Attributes:   command.Attributes,
+		Type:         command.Type,
+		Components:   command.Components,
+		Version:      command.Version,
+	}
+
+	for _, variant := range productDto.Variants {
+		if variant.ID != uuid.Nil {
+			variant.Quantity = 0
+		}
+	}
+	product.prepareVariants(productDto.ID, productDto.Variants)
+	product.preparePromotions(productDto.Promotions)
+
+	productPostgresCurrent, err := product.productPostgresRepository.FindByID(ctx, productDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if productPostgresCurrent == nil {
+		return nil, models.NewNotFoundError("product not found")
+	}
+
+	if len(productDto.Status) == 0 {
+		productDto.Status = productPostgresCurrent.Status
+		productDto.PublishAt = productPostgresCurrent.PublishAt
+	}
+
+	if len(productDto.Type) == 0 {
+		productDto.Type = productPostgresCurrent.Type
+	}
+	if productDto.Type != productPostgresCurrent.Type {
+		return nil, errors.New("product type cannot be changed")
+	}
+
+	if len(strings.TrimSpace(productDto.Slug)) == 0 {
+		productDto.Slug = productPostgresCurrent.Slug
+	} else {
+		productDto.Slug, err = product.prepareSlug(ctx, productDto.ID, productDto.Name, productDto.Slug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = product.prepareTranslations(ctx, productDto.ID, productDto.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.prepareAttributes(ctx, productDto.Attributes)
+	if err != nil {
+		return nil, err
 	}
 
 	result := validators.ValidateUpdateProduct(productDto)
@@ -130,14 +473,27 @@ func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Co
 	}
 
 	productModel := &models.Product{
-		ID:          productDto.ID,
-		Name:        productDto.Name,
-		Slug:        productDto.Slug,
-		Description: productDto.Description,
-		Price:       productDto.Price,
-		Image:       productDto.Image,
-		Version:     productDto.Version,
-		UpdatedAt:   time.Now().UTC(),
+		ID:           productDto.ID,
+		Name:         productDto.Name,
+		Slug:         productDto.Slug,
+		Description:  productDto.Description,
+		Price:        productDto.Price,
+		Image:        productDto.Image,
+		Categories:   productDto.Categories,
+		BrandID:      productDto.BrandID,
+		Options:      productDto.Options,
+		Variants:     productDto.Variants,
+		Prices:       productDto.Prices,
+		Promotions:   productDto.Promotions,
+		Status:       productDto.Status,
+		PublishAt:    productDto.PublishAt,
+		Translations: productDto.Translations,
+		Attributes:   productDto.Attributes,
+		Type:         productDto.Type,
+		Components:   productDto.Components,
+		Relations:    productPostgresCurrent.Relations,
+		Version:      productDto.Version,
+		UpdatedAt:    time.Now().UTC(),
 	}
 
 	productPostgresExists, _ := product.productPostgresRepository.FindByName(ctx, productModel.Name)
@@ -145,38 +501,492 @@ func (product *ProductCommandHandler) UpdateProductCommandHandler(ctx context.Co
 		return nil, errors.New("product with this name already exists with another id")
 	}
 
-	productModel, err := product.productPostgresRepository.Update(ctx, productModel)
+	err = product.checkStatus(productModel, productPostgresCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkCategories(ctx, productModel.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkBrand(ctx, productModel.BrandID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkComponents(ctx, productModel.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	err = product.checkProductPrices(ctx, productModel)
+	if err != nil {
+		return nil, err
+	}
+
+	return product.productPostgresRepository.Update(ctx, productModel)
+}
+
+func (product *ProductCommandHandler) ChangeProductStatusCommandHandler(ctx context.Context, command *commands.ChangeProductStatusCommand) (*models.Product, error) {
+	productDto := &dtos.ChangeProductStatus{
+		ID:        command.ID,
+		Status:    command.Status,
+		PublishAt: command.PublishAt,
+	}
+
+	result := validators.ValidateChangeProductStatus(productDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	productModel, err := product.productPostgresRepository.FindByID(ctx, productDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if productModel == nil {
+		return nil, models.NewNotFoundError("product not found")
+	}
+
+	productPostgresCurrent := *productModel
+	productModel.Status = productDto.Status
+	productModel.PublishAt = productDto.PublishAt
+
+	err = product.checkStatus(productModel, &productPostgresCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	productModel, err = product.productStatusRepository.UpdateStatus(ctx, productModel, productPostgresCurrent.Status)
 	if err != nil {
 		return nil, err
 	}
 
 	data, _ := json.Marshal(productModel)
 
-	storeEvent := &models.EventSourcing{
+	eventSourcing := &models.EventSourcing{
 		ID:          uuid.New(),
 		AggregateID: productModel.ID,
-		MessageType: "product.update",
+		MessageType: "product.status",
 		Timestamp:   time.Now().UTC(),
 		Data:        string(data),
 	}
-	go product.eventSourcingMongoRepository.Create(ctx, storeEvent)
+	go product.eventSourcingMongoRepository.Create(ctx, eventSourcing)
 
-	productEvent := &events.ProductUpdatedEvent{
+	for _, variant := range productModel.Variants {
+		variant.Quantity = 0
+	}
+
+	product.productUpdated(ctx, productModel, eventSourcing)
+
+	return productModel, nil
+}
+
+func (product *ProductCommandHandler) SetProductRelationsCommandHandler(ctx context.Context, command *commands.SetProductRelationsCommand) (*models.Product, error) {
+	productDto := &dtos.SetProductRelations{
+		ID:        command.ID,
+		Relations: command.Relations,
+		Version:   command.Version,
+	}
+
+	result := validators.ValidateSetProductRelations(productDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	productModel, err := product.productPostgresRepository.FindByID(ctx, productDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if productModel == nil {
+		return nil, models.NewNotFoundError("product not found")
+	}
+
+	if productDto.Version != productModel.Version {
+		return nil, models.NewConflictError("product has been changed by another request")
+	}
+
+	positions := map[string]uint{}
+	for _, relation := range productDto.Relations {
+		related, err := product.productPostgresRepository.FindByID(ctx, relation.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if related == nil {
+			return nil, fmt.Errorf("related product id: %v not found", relation.ProductID)
+		}
+
+		relation.Position = positions[relation.Type]
+		positions[relation.Type]++
+	}
+
+	productModel.Relations = productDto.Relations
+	productModel, err = product.productRelationRepository.UpdateRelations(ctx, productModel)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(productModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
 		AggregateID: productModel.ID,
-		MessageType: storeEvent.MessageType,
-		Timestamp:   storeEvent.Timestamp,
+		MessageType: "product.relations",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go product.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+
+	for _, variant := range productModel.Variants {
+		variant.Quantity = 0
+	}
+
+	product.productUpdated(ctx, productModel, eventSourcing)
+
+	return productModel, nil
+}
+
+func (product *ProductCommandHandler) DeleteProductCommandHandler(ctx context.Context, command *commands.DeleteProductCommand) error {
+	productModel, err := product.productPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if productModel == nil {
+		return models.NewNotFoundError("product not found")
+	}
+
+	err = product.productPostgresRepository.Delete(ctx, productModel.ID)
+	if err != nil {
+		return err
+	}
+
+	productModel.Deleted = true
+	data, _ := json.Marshal(productModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: productModel.ID,
+		MessageType: "product.delete",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go product.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+
+	productEvent := &events.ProductDeletedEvent{
+		AggregateID: productModel.ID,
+		MessageType: eventSourcing.MessageType,
+		Timestamp:   eventSourcing.Timestamp,
 		ID:          productModel.ID,
-		Name:        productModel.Name,
-		Slug:        productModel.Slug,
-		Description: productModel.Description,
-		Price:       productModel.Price,
-		Quantity:    productModel.Quantity,
-		Image:       productModel.Image,
-		UpdatedAt:   productModel.UpdatedAt,
-		Version:     productModel.Version,
 	}
 
-	go product.postgresEventHandler.ProductUpdatedEventHandler(ctx, productEvent)
+	go product.postgresEventHandler.ProductDeletedEventHandler(ctx, productEvent)
+
+	return nil
+}
+
+func (product *ProductCommandHandler) RestoreProductCommandHandler(ctx context.Context, command *commands.RestoreProductCommand) (*models.Product, error) {
+	productModel, err := product.productPostgresRepository.Restore(ctx, command.ID)
+	if err != nil {
+		return nil, err
+	}
+	if productModel == nil {
+		return nil, models.NewNotFoundError("deleted product not found")
+	}
+
+	data, _ := json.Marshal(productModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: productModel.ID,
+		MessageType: "product.restore",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go product.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+
+	productEvent := &events.ProductRestoredEvent{
+		AggregateID: productModel.ID,
+		MessageType: eventSourcing.MessageType,
+		Timestamp:   eventSourcing.Timestamp,
+		ID:          productModel.ID,
+	}
+
+	go product.postgresEventHandler.ProductRestoredEventHandler(ctx, productEvent)
 
 	return productModel, nil
 }
+
+func (product *ProductCommandHandler) checkStatus(productModel *models.Product, current *models.Product) error {
+	now := time.Now().UTC()
+
+	if current == nil && productModel.Status == models.ProductArchived {
+		return errors.New("product cannot be created as archived")
+	}
+
+	if current != nil && current.Status != productModel.Status {
+		allowed := false
+		for _, status := range productTransitions[current.Status] {
+			if status == productModel.Status {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return fmt.Errorf("product status cannot change from %s to %s", current.Status, productModel.Status)
+		}
+	}
+
+	switch productModel.Status {
+	case models.ProductScheduled:
+		if !productModel.PublishAt.After(now) {
+			return errors.New("publish date must be in the future")
+		}
+		productModel.PublishAt = productModel.PublishAt.UTC()
+	case models.ProductPublished:
+		if current != nil && current.Status == models.ProductPublished {
+			productModel.PublishAt = current.PublishAt
+		} else {
+			productModel.PublishAt = now
+		}
+	case models.ProductArchived:
+		productModel.PublishAt = current.PublishAt
+	default:
+		productModel.PublishAt = time.Time{}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) prepareSlug(ctx context.Context, productID uuid.UUID, name string, slug string) (string, error) {
+	generated := len(strings.TrimSpace(slug)) == 0
+	if generated {
+		slug = name
+	}
+
+	base := helpers.Slugify(slug)
+	if len(base) == 0 {
+		return "", nil
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		productExists, err := product.productPostgresRepository.FindBySlug(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if productExists == nil || productExists.ID == productID {
+			return candidate, nil
+		}
+		if !generated {
+			return "", models.NewConflictError(fmt.Sprintf("product with the slug %s already exists with another id", candidate))
+		}
+
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (product *ProductCommandHandler) prepareTranslations(ctx context.Context, productID uuid.UUID, translations []*models.ProductTranslation) error {
+	for _, translation := range translations {
+		translation.Locale = strings.TrimSpace(translation.Locale)
+		if len(strings.TrimSpace(translation.Slug)) == 0 {
+			translation.Slug = translation.Name
+		}
+		translation.Slug = helpers.Slugify(translation.Slug)
+		if len(translation.Slug) == 0 {
+			continue
+		}
+
+		productExists, err := product.productPostgresRepository.FindBySlug(ctx, translation.Slug)
+		if err != nil {
+			return err
+		}
+		if productExists != nil && productExists.ID != productID {
+			return models.NewConflictError(fmt.Sprintf("product with the %s slug %s already exists with another id", translation.Locale, translation.Slug))
+		}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) prepareAttributes(ctx context.Context, attributes []*models.ProductAttribute) error {
+	for _, attribute := range attributes {
+		var definition *models.Attribute
+		var err error
+		if attribute.AttributeID != uuid.Nil {
+			definition, err = product.attributePostgresRepository.FindByID(ctx, attribute.AttributeID)
+		} else {
+			definition, err = product.attributePostgresRepository.FindByCode(ctx, strings.TrimSpace(attribute.Code))
+		}
+		if err != nil {
+			return err
+		}
+
+		if definition == nil {
+			return fmt.Errorf("attribute %s not found", attribute.Code)
+		}
+
+		attribute.AttributeID = definition.ID
+		attribute.Code = definition.Code
+		attribute.Type = definition.Type
+		attribute.Definition = definition
+	}
+
+	return nil
+}
+
+// checkProductPrices resolves the price lists of the product and variant prices and gives list promotions
+// the currency of the list they discount.
+func (product *ProductCommandHandler) checkProductPrices(ctx context.Context, productModel *models.Product) error {
+	err := product.checkPrices(ctx, productModel.Prices)
+	if err != nil {
+		return err
+	}
+
+	for _, variant := range productModel.Variants {
+		err = product.checkPrices(ctx, variant.Prices)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, promotion := range productModel.Promotions {
+		if !promotion.PriceListID.Valid {
+			continue
+		}
+
+		price := models.ListPrice(productModel.Prices, promotion.PriceListID.UUID)
+		if price == nil {
+			return fmt.Errorf("promotion price list id: %v has no product price", promotion.PriceListID.UUID)
+		}
+
+		if len(promotion.Price.Currency) == 0 {
+			promotion.Price.Currency = price.Currency
+		}
+
+		if promotion.Price.Currency != price.Currency {
+			return fmt.Errorf("promotion price must be in %s", price.Currency)
+		}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) checkPrices(ctx context.Context, prices []*models.ProductPrice) error {
+	for _, price := range prices {
+		priceList, err := product.priceListPostgresRepository.FindByID(ctx, price.PriceListID)
+		if err != nil {
+			return err
+		}
+
+		if priceList == nil {
+			return fmt.Errorf("price list id: %v not found", price.PriceListID)
+		}
+
+		if len(price.Price.Currency) == 0 {
+			price.Price.Currency = priceList.Currency
+		}
+
+		if price.Price.Currency != priceList.Currency {
+			return fmt.Errorf("price list %s requires currency %s", priceList.Code, priceList.Currency)
+		}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) checkBrand(ctx context.Context, brandID uuid.NullUUID) error {
+	if !brandID.Valid {
+		return nil
+	}
+
+	brand, err := product.brandPostgresRepository.FindByID(ctx, brandID.UUID)
+	if err != nil {
+		return err
+	}
+
+	if brand == nil {
+		return fmt.Errorf("brand id: %v not found", brandID.UUID)
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) checkComponents(ctx context.Context, components []*models.ProductComponent) error {
+	for _, component := range components {
+		componentProduct, err := product.productPostgresRepository.FindByID(ctx, component.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if componentProduct == nil {
+			return fmt.Errorf("component product id: %v not found", component.ProductID)
+		}
+
+		if componentProduct.Type == models.ProductBundle {
+			return fmt.Errorf("component product %s cannot be a bundle", componentProduct.Name)
+		}
+
+		if len(componentProduct.Variants) > 0 && !component.VariantID.Valid {
+			return fmt.Errorf("component product %s requires a variant", componentProduct.Name)
+		}
+
+		if component.VariantID.Valid && !product.hasVariant(componentProduct.Variants, component.VariantID.UUID) {
+			return fmt.Errorf("variant id: %v not found in component product %s", component.VariantID.UUID, componentProduct.Name)
+		}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) hasVariant(variants []*models.ProductVariant, variantID uuid.UUID) bool {
+	for _, variant := range variants {
+		if variant.ID == variantID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (product *ProductCommandHandler) checkCategories(ctx context.Context, categories []uuid.UUID) error {
+	for _, categoryID := range categories {
+		category, err := product.categoryPostgresRepository.FindByID(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+
+		if category == nil {
+			return fmt.Errorf("category id: %v not found", categoryID)
+		}
+	}
+
+	return nil
+}
+
+func (product *ProductCommandHandler) preparePromotions(promotions []*models.ProductPromotion) {
+	for _, promotion := range promotions {
+		if promotion.ID == uuid.Nil {
+			promotion.ID = uuid.New()
+		}
+
+		promotion.StartsAt = promotion.StartsAt.UTC()
+		promotion.EndsAt = promotion.EndsAt.UTC()
+	}
+}
+
+func (product *ProductCommandHandler) prepareVariants(productID uuid.UUID, variants []*models.ProductVariant) uint {
+	var quantity uint
+	for _, variant := range variants {
+		if variant.ID == uuid.Nil {
+			variant.ID = uuid.New()
+			variant.CreatedAt = time.Now().UTC()
+		}
+
+		variant.ProductID = productID
+		quantity += variant.Quantity
+	}
+
+	return quantity
+}
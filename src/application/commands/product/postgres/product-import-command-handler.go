@@ -0,0 +1,255 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	commands "product/src/application/commands/product"
+	command_store "product/src/application/commands/store"
+	postgres_store_command_handler "product/src/application/commands/store/postgres"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/models"
+	"product/src/validators"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductImportCommandHandler struct {
+	productPostgresRepository       repository_interface.ProductRepository
+	productImportPostgresRepository repository_interface.ProductImportRepository
+	productCommandHandler           *ProductCommandHandler
+	storeCommandHandler             *postgres_store_command_handler.StoreCommandHandler
+}
+
+var importProgressSize = 100
+
+func NewProductImportCommandHandler(
+	productPostgresRepository repository_interface.ProductRepository,
+	productImportPostgresRepository repository_interface.ProductImportRepository,
+	productCommandHandler *ProductCommandHandler,
+	storeCommandHandler *postgres_store_command_handler.StoreCommandHandler,
+) *ProductImportCommandHandler {
+	return &ProductImportCommandHandler{
+		productPostgresRepository:       productPostgresRepository,
+		productImportPostgresRepository: productImportPostgresRepository,
+		productCommandHandler:           productCommandHandler,
+		storeCommandHandler:             storeCommandHandler,
+	}
+}
+
+func (handler *ProductImportCommandHandler) ImportProductsCommandHandler(ctx context.Context, command *commands.ImportProductsCommand) (*models.ProductImport, error) {
+	importDto := &dtos.ImportProducts{
+		ID:     command.ID,
+		Format: strings.ToLower(strings.TrimSpace(command.Format)),
+		Size:   len(command.Data),
+	}
+
+	result := validators.ValidateImportProducts(importDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	rows, err := handler.parse(importDto.Format, command.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("import file has no rows")
+	}
+	if len(rows) > models.ImportMaxRows {
+		return nil, fmt.Errorf("import file must not exceed %d rows", models.ImportMaxRows)
+	}
+
+	productImport := &models.ProductImport{
+		ID:        importDto.ID,
+		Format:    importDto.Format,
+		Status:    models.ImportRunning,
+		Total:     uint(len(rows)),
+		Rows:      []*models.ProductImportRow{},
+		CreatedBy: command.CreatedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	productImport, err = handler.productImportPostgresRepository.Create(ctx, productImport)
+	if err != nil {
+		return nil, err
+	}
+
+	go handler.run(context.Background(), productImport, rows)
+
+	return productImport, nil
+}
+
+// FailInterruptedImports marks imports left running by a previous process as
+// failed, since their goroutine did not survive the restart.
+func (handler *ProductImportCommandHandler) FailInterruptedImports(ctx context.Context) error {
+	count, err := handler.productImportPostgresRepository.FailRunning(ctx, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("%d interrupted product imports marked as failed", count)
+	}
+
+	return nil
+}
+
+func (handler *ProductImportCommandHandler) run(ctx context.Context, productImport *models.ProductImport, rows []*productImportRow) {
+	defer func() {
+		if r := recover(); r != nil {
+			productImport.Status = models.ImportFailed
+			productImport.FinishedAt = time.Now().UTC()
+			handler.save(ctx, productImport)
+			log.Printf("product import %s failed: %v", productImport.ID, r)
+		}
+	}()
+
+	for i, row := range rows {
+		report := handler.importRow(ctx, uint(i+1), row)
+		productImport.Rows = append(productImport.Rows, report)
+		if len(report.Error) == 0 {
+			productImport.Succeeded++
+		} else {
+			productImport.Failed++
+		}
+
+		if (i+1)%importProgressSize == 0 {
+			handler.save(ctx, productImport)
+		}
+	}
+
+	productImport.Status = models.ImportCompleted
+	productImport.FinishedAt = time.Now().UTC()
+	handler.save(ctx, productImport)
+}
+
+func (handler *ProductImportCommandHandler) save(ctx context.Context, productImport *models.ProductImport) {
+	_, err := handler.productImportPostgresRepository.Update(ctx, productImport)
+	if err != nil {
+		log.Printf("product import %s update error: %v", productImport.ID, err)
+	}
+}
+
+func (handler *ProductImportCommandHandler) importRow(ctx context.Context, number uint, row *productImportRow) *models.ProductImportRow {
+	report := &models.ProductImportRow{Row: number}
+	if row.err != nil {
+		report.Error = row.err.Error()
+		return report
+	}
+
+	existing, err := handler.findExisting(ctx, row.command)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	var productModel *models.Product
+	if existing == nil {
+		if row.command.ID == uuid.Nil {
+			row.command.ID = uuid.New()
+		}
+		report.Action = models.ImportCreated
+		productModel, err = handler.productCommandHandler.CreateProductCommandHandler(ctx, row.command)
+	} else {
+		report.Action = models.ImportUpdated
+		productModel, err = handler.update(ctx, existing, row.command)
+	}
+	if err != nil {
+		report.Action = ""
+		report.Error = err.Error()
+		return report
+	}
+
+	report.ProductID = productModel.ID
+
+	return report
+}
+
+func (handler *ProductImportCommandHandler) findExisting(ctx context.Context, command *commands.CreateProductCommand) (*models.Product, error) {
+	if command.ID != uuid.Nil {
+		return handler.productPostgresRepository.FindByID(ctx, command.ID)
+	}
+
+	slug := strings.TrimSpace(command.Slug)
+	if len(slug) == 0 {
+		return nil, nil
+	}
+
+	return handler.productPostgresRepository.FindBySlug(ctx, slug)
+}
+
+func (handler *ProductImportCommandHandler) update(ctx context.Context, existing *models.Product, command *commands.CreateProductCommand) (*models.Product, error) {
+	quantities := map[uuid.UUID]uint{}
+	for _, variant := range command.Variants {
+		if variant.ID != uuid.Nil {
+			quantities[variant.ID] = variant.Quantity
+		}
+	}
+
+	updateProductCommand := &commands.UpdateProductCommand{
+		ID:           existing.ID,
+		Name:         command.Name,
+		Slug:         command.Slug,
+		Description:  command.Description,
+		Price:        command.Price,
+		Image:        command.Image,
+		Categories:   command.Categories,
+		BrandID:      command.BrandID,
+		Options:      command.Options,
+		Variants:     command.Variants,
+		Prices:       command.Prices,
+		Promotions:   command.Promotions,
+		Status:       command.Status,
+		PublishAt:    command.PublishAt,
+		Translations: command.Translations,
+		Attributes:   command.Attributes,
+		Type:         command.Type,
+		Components:   command.Components,
+		Version:      existing.Version,
+	}
+
+	productModel, err := handler.productCommandHandler.UpdateProductCommandHandler(ctx, updateProductCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	if productModel.Type == models.ProductBundle {
+		return productModel, nil
+	}
+
+	if len(productModel.Variants) == 0 {
+		return productModel, handler.setStock(ctx, productModel.ID, uuid.NullUUID{}, command.Quantity)
+	}
+
+	for _, variant := range productModel.Variants {
+		quantity, ok := quantities[variant.ID]
+		if !ok {
+			continue
+		}
+
+		err = handler.setStock(ctx, productModel.ID, uuid.NullUUID{UUID: variant.ID, Valid: true}, quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return productModel, nil
+}
+
+func (handler *ProductImportCommandHandler) setStock(ctx context.Context, productID uuid.UUID, variantID uuid.NullUUID, quantity uint) error {
+	setStockCommand := &command_store.SetStockCommand{
+		ProductID: productID,
+		VariantID: variantID,
+		Quantity:  quantity,
+		Reason:    models.StockAdjustmentReason,
+	}
+
+	return handler.storeCommandHandler.SetStockCommandHandler(ctx, setStockCommand)
+}
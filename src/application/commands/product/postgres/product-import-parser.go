@@ -0,0 +1,212 @@
+package postgres_command
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	commands "product/src/application/commands/product"
+	"product/src/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type productImportRow struct {
+	command *commands.CreateProductCommand
+	err     error
+}
+
+var importColumns = map[string]bool{
+	"id":           true,
+	"name":         true,
+	"slug":         true,
+	"description":  true,
+	"price":        true,
+	"currency":     true,
+	"quantity":     true,
+	"image":        true,
+	"status":       true,
+	"type":         true,
+	"brandid":      true,
+	"categories":   true,
+	"publish_at":   true,
+	"rating":       false,
+	"review_count": false,
+	"created_at":   false,
+	"updated_at":   false,
+	"version":      false,
+	"deleted":      false,
+}
+
+func (handler *ProductImportCommandHandler) parse(format string, data []byte) ([]*productImportRow, error) {
+	switch format {
+	case models.FormatCSV:
+		return handler.parseCSV(data)
+	case models.FormatJSON:
+		return handler.parseJSON(data)
+	case models.FormatNDJSON:
+		return handler.parseNDJSON(data)
+	default:
+		return nil, fmt.Errorf("import format %s is not supported", format)
+	}
+}
+
+func (handler *ProductImportCommandHandler) parseJSON(data []byte) ([]*productImportRow, error) {
+	values := []json.RawMessage{}
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json: %v", err)
+	}
+
+	rows := []*productImportRow{}
+	for _, value := range values {
+		rows = append(rows, handler.decodeRow(value))
+	}
+
+	return rows, nil
+}
+
+func (handler *ProductImportCommandHandler) parseNDJSON(data []byte) ([]*productImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), models.ImportMaxSize)
+
+	rows := []*productImportRow{}
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		rows = append(rows, handler.decodeRow(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid ndjson: %v", err)
+	}
+
+	return rows, nil
+}
+
+func (handler *ProductImportCommandHandler) decodeRow(data []byte) *productImportRow {
+	command := &commands.CreateProductCommand{}
+	err := json.Unmarshal(data, command)
+	if err != nil {
+		return &productImportRow{err: fmt.Errorf("invalid json: %v", err)}
+	}
+
+	return &productImportRow{command: command}
+}
+
+func (handler *ProductImportCommandHandler) parseCSV(data []byte) ([]*productImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %v", err)
+	}
+
+	hasName := false
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := importColumns[column]; !ok {
+			return nil, fmt.Errorf("unknown csv column: %s", column)
+		}
+		if column == "name" {
+			hasName = true
+		}
+		header[i] = column
+	}
+	if !hasName {
+		return nil, fmt.Errorf("csv column name is required")
+	}
+
+	rows := []*productImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rows = append(rows, &productImportRow{err: err})
+			continue
+		}
+
+		command, err := handler.csvRow(header, record)
+		rows = append(rows, &productImportRow{command: command, err: err})
+	}
+
+	return rows, nil
+}
+
+func (handler *ProductImportCommandHandler) csvRow(header []string, record []string) (*commands.CreateProductCommand, error) {
+	command := &commands.CreateProductCommand{}
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if len(value) == 0 {
+			continue
+		}
+
+		switch column {
+		case "id":
+			ID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid id: %s", value)
+			}
+			command.ID = ID
+		case "name":
+			command.Name = value
+		case "slug":
+			command.Slug = value
+		case "description":
+			command.Description = value
+		case "price":
+			amount, err := decimal.NewFromString(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price: %s", value)
+			}
+			command.Price.Amount = amount
+		case "currency":
+			command.Price.Currency = strings.ToUpper(value)
+		case "quantity":
+			quantity, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quantity: %s", value)
+			}
+			command.Quantity = uint(quantity)
+		case "image":
+			command.Image = value
+		case "status":
+			command.Status = value
+		case "type":
+			command.Type = value
+		case "brandid":
+			brandID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid brandid: %s", value)
+			}
+			command.BrandID = uuid.NullUUID{UUID: brandID, Valid: true}
+		case "categories":
+			for _, category := range strings.Split(value, "|") {
+				categoryID, err := uuid.Parse(strings.TrimSpace(category))
+				if err != nil {
+					return nil, fmt.Errorf("invalid category: %s", category)
+				}
+				command.Categories = append(command.Categories, categoryID)
+			}
+		case "publish_at":
+			publishAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid publish_at: %s", value)
+			}
+			command.PublishAt = publishAt
+		}
+	}
+
+	return command, nil
+}
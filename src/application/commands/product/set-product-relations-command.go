@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type SetProductRelationsCommand struct {
+	AggregateID uuid.UUID                 `json:"aggregateId"`
+	MessageType string                    `json:"messageType"`
+	Timestamp   time.Time                 `json:"timestamp"`
+	ID          uuid.UUID                 `json:"id"`
+	Relations   []*models.ProductRelation `json:"relations"`
+	Version     uint                      `json:"version"`
+}
@@ -1,20 +1,34 @@
 package commands
 
 import (
+	"product/src/models"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type UpdateProductCommand struct {
-	AggregateID uuid.UUID `json:"aggregateId"`
-	MessageType string    `json:"messageType"`
-	Timestamp   time.Time `json:"timestamp"`
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description,omitempty"`
-	Price       float32   `json:"price"`
-	Image       string    `json:"image,omitempty"`
-	Version     uint      `json:"version"`
+	AggregateID  uuid.UUID                    `json:"aggregateId"`
+	MessageType  string                       `json:"messageType"`
+	Timestamp    time.Time                    `json:"timestamp"`
+	ID           uuid.UUID                    `json:"id"`
+	Name         string                       `json:"name"`
+	Slug         string                       `json:"slug"`
+	Description  string                       `json:"description,omitempty"`
+	Price        models.Money                 `json:"price"`
+	Image        string                       `json:"image,omitempty"`
+	Categories   []uuid.UUID                  `json:"categories,omitempty"`
+	BrandID      uuid.NullUUID                `json:"brandid"`
+	Options      []*models.ProductOption      `json:"options,omitempty"`
+	Variants     []*models.ProductVariant     `json:"variants,omitempty"`
+	Prices       []*models.ProductPrice       `json:"prices,omitempty"`
+	Promotions   []*models.ProductPromotion   `json:"promotions,omitempty"`
+	Status       string                       `json:"status"`
+	PublishAt    time.Time                    `json:"publish_at,omitempty"`
+	Translations []*models.ProductTranslation `json:"translations,omitempty"`
+	Attributes   []*models.ProductAttribute   `json:"attributes,omitempty"`
+	Type         string                       `json:"type"`
+	Components   []*models.ProductComponent   `json:"components,omitempty"`
+	Relations    []*models.ProductRelation    `json:"relations,omitempty"`
+	Version      uint                         `json:"version"`
 }
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateReviewCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"productid"`
+	UserID      string    `json:"-"`
+	Rating      uint      `json:"rating"`
+	Title       string    `json:"title,omitempty"`
+	Comment     string    `json:"comment,omitempty"`
+}
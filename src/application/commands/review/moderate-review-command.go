@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ModerateReviewCommand struct {
+	AggregateID uuid.UUID `json:"aggregateId"`
+	MessageType string    `json:"messageType"`
+	Timestamp   time.Time `json:"timestamp"`
+	ID          uuid.UUID `json:"id"`
+	Status      string    `json:"status"`
+	Version     uint      `json:"version"`
+}
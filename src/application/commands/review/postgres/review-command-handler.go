@@ -0,0 +1,195 @@
+package postgres_command
+
+import (
+	"context"
+	"errors"
+	command_product "product/src/application/commands/product"
+	commands "product/src/application/commands/review"
+	"strings"
+
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"product/src/dtos"
+	"product/src/models"
+	"product/src/nats/subjects"
+	"product/src/validators"
+	"time"
+
+	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type ReviewCommandHandler struct {
+	reviewPostgresRepository     repository_interface.ReviewRepository
+	productPostgresRepository    repository_interface.ProductRepository
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository
+	publisher                    common_nats.Publisher
+}
+
+func NewReviewCommandHandler(
+	reviewPostgresRepository repository_interface.ReviewRepository,
+	productPostgresRepository repository_interface.ProductRepository,
+	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
+	publisher common_nats.Publisher,
+) *ReviewCommandHandler {
+	common_validator.NewValidator("en")
+	return &ReviewCommandHandler{
+		reviewPostgresRepository:     reviewPostgresRepository,
+		productPostgresRepository:    productPostgresRepository,
+		eventSourcingMongoRepository: eventSourcingMongoRepository,
+		publisher:                    publisher,
+	}
+}
+
+func (review *ReviewCommandHandler) CreateReviewCommandHandler(ctx context.Context, command *commands.CreateReviewCommand) (*models.Review, error) {
+	reviewDto := &dtos.AddReview{
+		ID:        command.ID,
+		ProductID: command.ProductID,
+		UserID:    command.UserID,
+		Rating:    command.Rating,
+		Title:     strings.TrimSpace(command.Title),
+		Comment:   strings.TrimSpace(command.Comment),
+	}
+
+	result := validators.ValidateAddReview(reviewDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	productModel, err := review.productPostgresRepository.FindByID(ctx, reviewDto.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if productModel == nil || productModel.Status != models.ProductPublished {
+		return nil, errors.New("product not found")
+	}
+
+	reviewExists, err := review.reviewPostgresRepository.FindByUser(ctx, reviewDto.ProductID, reviewDto.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewExists != nil {
+		return nil, errors.New("review already exists")
+	}
+
+	reviewModel := &models.Review{
+		ID:        reviewDto.ID,
+		ProductID: reviewDto.ProductID,
+		UserID:    reviewDto.UserID,
+		Rating:    reviewDto.Rating,
+		Title:     reviewDto.Title,
+		Comment:   reviewDto.Comment,
+		Status:    models.ReviewPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	reviewModel, err = review.reviewPostgresRepository.Create(ctx, reviewModel)
+	if err != nil {
+		return nil, err
+	}
+
+	review.createEventSourcing(ctx, reviewModel, "review.create")
+
+	return reviewModel, nil
+}
+
+func (review *ReviewCommandHandler) ModerateReviewCommandHandler(ctx context.Context, command *commands.ModerateReviewCommand) (*models.Review, error) {
+	reviewDto := &dtos.ModerateReview{
+		ID:      command.ID,
+		Status:  command.Status,
+		Version: command.Version,
+	}
+
+	result := validators.ValidateModerateReview(reviewDto)
+	if result != nil {
+		return nil, errors.New(strings.Join(result.([]string), ""))
+	}
+
+	reviewModel, err := review.reviewPostgresRepository.FindByID(ctx, reviewDto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewModel == nil {
+		return nil, errors.New("review not found")
+	}
+
+	if reviewDto.Version != reviewModel.Version {
+		return nil, models.NewConflictError("review has been changed by another request")
+	}
+
+	approved := reviewModel.Status == models.ReviewApproved
+	reviewModel.Status = reviewDto.Status
+	reviewModel, err = review.reviewPostgresRepository.UpdateStatus(ctx, reviewModel)
+	if err != nil {
+		return nil, err
+	}
+
+	review.createEventSourcing(ctx, reviewModel, "review.moderate")
+
+	if approved || reviewModel.Status == models.ReviewApproved {
+		err = review.updateRating(ctx, reviewModel.ProductID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reviewModel, nil
+}
+
+func (review *ReviewCommandHandler) DeleteReviewCommandHandler(ctx context.Context, command *commands.DeleteReviewCommand) error {
+	reviewModel, err := review.reviewPostgresRepository.FindByID(ctx, command.ID)
+	if err != nil {
+		return err
+	}
+	if reviewModel == nil {
+		return errors.New("review not found")
+	}
+
+	err = review.reviewPostgresRepository.Delete(ctx, reviewModel.ID)
+	if err != nil {
+		return err
+	}
+
+	reviewModel.Deleted = true
+	review.createEventSourcing(ctx, reviewModel, "review.delete")
+
+	if reviewModel.Status == models.ReviewApproved {
+		return review.updateRating(ctx, reviewModel.ProductID)
+	}
+
+	return nil
+}
+
+func (review *ReviewCommandHandler) updateRating(ctx context.Context, productID uuid.UUID) error {
+	rating, err := review.reviewPostgresRepository.UpdateRating(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	ratingCommand := &command_product.ChangeProductRatingCommand{
+		AggregateID: productID,
+		MessageType: "product.rating",
+		Timestamp:   time.Now().UTC(),
+		ID:          productID,
+		Rating:      rating.Rating,
+		ReviewCount: rating.ReviewCount,
+	}
+
+	data, _ := json.Marshal(ratingCommand)
+	return review.publisher.Publish(string(subjects.ProductRatingMongo), data)
+}
+
+func (review *ReviewCommandHandler) createEventSourcing(ctx context.Context, reviewModel *models.Review, messageType string) {
+	data, _ := json.Marshal(reviewModel)
+
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: reviewModel.ID,
+		MessageType: messageType,
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+	go review.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+}
@@ -14,7 +14,9 @@ type BookStoreCommand struct {
 	MessageType string    `json:"messageType"`
 	Timestamp   time.Time `json:"timestamp"`
 	//ID          uuid.UUID         `json:"id"`
-	OrderID primitive.ObjectID `json:"orderId"`
+	OrderID    primitive.ObjectID `json:"orderId"`
+	CustomerID primitive.ObjectID `json:"customerId"`
+	TTLSeconds uint               `json:"ttlSeconds"`
 	//ProductID   uuid.UUID         `json:"productId"`
 	Products []*models.Product `json:"products"`
 	Stores   []*models.Store   `json:"stores"`
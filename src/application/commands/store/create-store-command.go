@@ -13,6 +13,7 @@ type CreateStoreCommand struct {
 	Timestamp   time.Time       `json:"timestamp"`
 	ID          uuid.UUID       `json:"id"`
 	ProductID   uuid.UUID       `json:"productId"`
+	VariantID   uuid.NullUUID   `json:"variantId"`
 	Quantity    uint            `json:"quantity"`
 	Stores      []*models.Store `json:"stores"`
 	CreatedAt   time.Time       `json:"created_at"`
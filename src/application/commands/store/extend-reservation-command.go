@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExtendReservationCommand struct {
+	AggregateID uuid.UUID          `json:"aggregateId"`
+	MessageType string             `json:"messageType"`
+	Timestamp   time.Time          `json:"timestamp"`
+	OrderID     primitive.ObjectID `json:"orderId"`
+	CustomerID  primitive.ObjectID `json:"customerId"`
+	TTLSeconds  uint               `json:"ttlSeconds"`
+	Admin       bool               `json:"-"`
+}
@@ -153,3 +153,25 @@ func (store *StoreCommandHandler) PaymentStoreCommandHandler(ctx context.Context
 
 	return nil
 }
+
+func (store *StoreCommandHandler) WriteOffStoreCommandHandler(ctx context.Context, command *commands.WriteOffStoreCommand) error {
+	for _, _store := range command.Stores {
+		err := store.storeMongoRepository.Delete(ctx, _store.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	storeEvent := &events.StoreWrittenOffEvent{
+		AggregateID: command.AggregateID,
+		MessageType: command.MessageType,
+		Timestamp:   time.Now().UTC(),
+		ProductID:   command.ProductID,
+		Reason:      command.Reason,
+		Stores:      command.Stores,
+	}
+
+	go store.mongoEventHandler.StoreWrittenOffEventHandler(storeEvent)
+
+	return nil
+}
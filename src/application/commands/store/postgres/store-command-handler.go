@@ -13,36 +13,49 @@ import (
 
 	"product/src/dtos"
 	"product/src/models"
+	"product/src/reservation"
 	"product/src/validators"
 	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
-	common_models "github.com/JohnSalazar/microservices-go-common/models"
 	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
 	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
 )
 
 type StoreCommandHandler struct {
 	storePostgresRepository      repository_interface.StoreRepository
+	storeStockRepository         repository_interface.StoreStockRepository
+	productBundleRepository      repository_interface.ProductBundleRepository
+	reservationRepository        repository_interface.ReservationRepository
 	eventSourcingMongoRepository repository_interface.EventSourcingRepository
 	postgresEventHandler         *postgres_event_handler.StoreEventHandler
 	publisher                    common_nats.Publisher
+	reservationConfig            *reservation.Config
 }
 
 func NewStoreCommandHandler(
 	storePostgresRepository repository_interface.StoreRepository,
+	storeStockRepository repository_interface.StoreStockRepository,
+	productBundleRepository repository_interface.ProductBundleRepository,
+	reservationRepository repository_interface.ReservationRepository,
 	eventSourcingMongoRepository repository_interface.EventSourcingRepository,
 	postgresEventHandler *postgres_event_handler.StoreEventHandler,
 	publisher common_nats.Publisher,
+	reservationConfig *reservation.Config,
 ) *StoreCommandHandler {
 	common_validator.NewValidator("en")
 	return &StoreCommandHandler{
 		storePostgresRepository:      storePostgresRepository,
+		storeStockRepository:         storeStockRepository,
+		productBundleRepository:      productBundleRepository,
+		reservationRepository:        reservationRepository,
 		eventSourcingMongoRepository: eventSourcingMongoRepository,
 		postgresEventHandler:         postgresEventHandler,
 		publisher:                    publisher,
+		reservationConfig:            reservationConfig,
 	}
 }
 
@@ -64,6 +77,7 @@ func (store *StoreCommandHandler) CreateStoreCommandHandler(ctx context.Context,
 		storeModel := &models.Store{
 			ID:        uuid.New(),
 			ProductID: storeDto.ProductID,
+			VariantID: command.VariantID,
 			BookedAt:  time.Time{},
 			Sold:      false,
 			CreatedAt: time.Now().UTC(),
@@ -105,6 +119,96 @@ func (store *StoreCommandHandler) CreateStoreCommandHandler(ctx context.Context,
 	return nil
 }
 
+func (store *StoreCommandHandler) WriteOffStoreCommandHandler(ctx context.Context, command *commands.WriteOffStoreCommand) error {
+	writeOffStoreDto := &dtos.WriteOffStore{
+		ProductID: command.ProductID,
+		Quantity:  command.Quantity,
+		Reason:    strings.TrimSpace(command.Reason),
+	}
+
+	result := validators.ValidateWriteOffStore(writeOffStoreDto)
+	if result != nil {
+		return errors.New(strings.Join(result.([]string), ""))
+	}
+
+	stores, err := store.storeStockRepository.WriteOff(ctx, writeOffStoreDto.ProductID, command.VariantID, writeOffStoreDto.Quantity)
+	if err != nil {
+		return err
+	}
+
+	eventsSourcing := []*models.EventSourcing{}
+	for _, _store := range stores {
+		data, _ := json.Marshal(struct {
+			*models.Store
+			Reason string `json:"reason"`
+		}{_store, writeOffStoreDto.Reason})
+		eventSourcing := &models.EventSourcing{
+			ID:          uuid.New(),
+			AggregateID: _store.ProductID,
+			MessageType: "store.write-off",
+			Timestamp:   time.Now().UTC(),
+			Data:        string(data),
+		}
+		eventsSourcing = append(eventsSourcing, eventSourcing)
+	}
+
+	go store.eventSourcingMongoRepository.CreateMany(ctx, eventsSourcing)
+
+	storeEvent := &events.StoreWrittenOffEvent{
+		AggregateID: writeOffStoreDto.ProductID,
+		MessageType: eventsSourcing[0].MessageType,
+		Timestamp:   eventsSourcing[0].Timestamp,
+		ProductID:   writeOffStoreDto.ProductID,
+		Reason:      writeOffStoreDto.Reason,
+		Stores:      stores,
+	}
+
+	go store.postgresEventHandler.StoreWrittenOffEventHandler(ctx, storeEvent)
+
+	return nil
+}
+
+func (store *StoreCommandHandler) SetStockCommandHandler(ctx context.Context, command *commands.SetStockCommand) error {
+	setStockDto := &dtos.SetStock{
+		ProductID: command.ProductID,
+		Quantity:  command.Quantity,
+		Reason:    strings.TrimSpace(command.Reason),
+	}
+
+	result := validators.ValidateSetStock(setStockDto)
+	if result != nil {
+		return errors.New(strings.Join(result.([]string), ""))
+	}
+
+	current, err := store.storeStockRepository.CountAvailable(ctx, setStockDto.ProductID, command.VariantID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case setStockDto.Quantity > current:
+		return store.CreateStoreCommandHandler(ctx, &commands.CreateStoreCommand{
+			ProductID: setStockDto.ProductID,
+			VariantID: command.VariantID,
+			Quantity:  setStockDto.Quantity - current,
+		})
+	case setStockDto.Quantity < current:
+		reason := setStockDto.Reason
+		if len(reason) == 0 {
+			reason = models.StockAdjustmentReason
+		}
+
+		return store.WriteOffStoreCommandHandler(ctx, &commands.WriteOffStoreCommand{
+			ProductID: setStockDto.ProductID,
+			VariantID: command.VariantID,
+			Quantity:  current - setStockDto.Quantity,
+			Reason:    reason,
+		})
+	default:
+		return nil
+	}
+}
+
 func (store *StoreCommandHandler) BookStoreCommandHandler(ctx context.Context, command *commands.BookStoreCommand) error {
 	bookStoreDto := &dtos.BookStore{
 		Products: command.Products,
@@ -115,61 +219,114 @@ func (store *StoreCommandHandler) BookStoreCommandHandler(ctx context.Context, c
 		return errors.New(strings.Join(result.([]string), ""))
 	}
 
-	updateStatusOrder := &dtos.UpdateStatusOrder{
-		ID:       command.OrderID,
-		Status:   uint(common_models.OrderCanceled),
-		StatusAt: time.Now().UTC(),
+	if command.OrderID.IsZero() {
+		return errors.New("order id is required")
 	}
-	dataUpdateStatusOrder, _ := json.Marshal(updateStatusOrder)
 
-	eventsSourcing := []*models.EventSourcing{}
-	listStores := []*models.Store{}
+	ttl, err := store.reservationConfig.TTL(command.TTLSeconds)
+	if err != nil {
+		return err
+	}
+
+	reservation, err := store.reservationRepository.FindByOrder(ctx, command.OrderID.Hex())
+	if err != nil {
+		return err
+	}
+
+	if reservation != nil && reservation.Status == models.ReservationActive {
+		if reservation.ExpiresAt.After(time.Now().UTC()) {
+			return models.NewConflictError(fmt.Sprintf("order %s already has an active reservation", command.OrderID.Hex()))
+		}
+
+		err = store.releaseExpired(ctx, reservation.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	bookings := []*models.StoreBooking{}
 	for _, product := range command.Products {
-		stores, err := store.storePostgresRepository.Book(ctx, product.ID, product.Quantity)
+		components, err := store.productBundleRepository.FindComponents(ctx, product.ID)
 		if err != nil {
 			return err
 		}
 
-		if len(stores) != int(product.Quantity) {
-			go store.publisher.Publish(string(common_nats.OrderStatus), dataUpdateStatusOrder)
-			return errors.New("not enough stores")
+		if len(components) > 0 {
+			for _, component := range components {
+				bookings = append(bookings, &models.StoreBooking{
+					BundleID:  uuid.NullUUID{UUID: product.ID, Valid: true},
+					ProductID: component.ProductID,
+					VariantID: component.VariantID,
+					Quantity:  component.Quantity * product.Quantity,
+				})
+			}
+			continue
 		}
 
-		for _, store := range stores {
-			store.BookedAt = time.Now().UTC().Add(1 * time.Minute)
-			store.Version++
-			store.UpdatedAt = time.Now().UTC()
+		variants := product.Variants
+		if len(variants) == 0 {
+			variants = []*models.ProductVariant{{Quantity: product.Quantity}}
 		}
 
-		stores, err = store.storePostgresRepository.Update(ctx, stores)
-		if err != nil {
-			go store.publisher.Publish(string(common_nats.OrderStatus), dataUpdateStatusOrder)
-			return err
+		for _, variant := range variants {
+			bookings = append(bookings, &models.StoreBooking{
+				ProductID: product.ID,
+				VariantID: uuid.NullUUID{UUID: variant.ID, Valid: variant.ID != uuid.Nil},
+				Quantity:  variant.Quantity,
+			})
 		}
+	}
 
-		listStores = append(listStores, stores...)
+	reservation = &models.Reservation{
+		ID:         uuid.New(),
+		OrderID:    command.OrderID.Hex(),
+		CustomerID: customerID(command.CustomerID),
+		Lines:      bookings,
+		Status:     models.ReservationActive,
+		ExpiresAt:  time.Now().UTC().Add(ttl),
+		CreatedAt:  time.Now().UTC(),
+	}
 
-		for _, store := range stores {
-			data, _ := json.Marshal(store)
-			eventSourcing := &models.EventSourcing{
-				ID:          uuid.New(),
-				AggregateID: store.ProductID,
-				MessageType: "store.book",
-				Timestamp:   time.Now().UTC(),
-				Data:        string(data),
-			}
-			eventsSourcing = append(eventsSourcing, eventSourcing)
+	stores, shortages, err := store.storePostgresRepository.BookOrder(ctx, reservation)
+	if err != nil {
+		return err
+	}
+
+	if len(shortages) > 0 {
+		data, _ := json.Marshal(shortages)
+		eventSourcing := &models.EventSourcing{
+			ID:          uuid.New(),
+			AggregateID: reservation.ID,
+			MessageType: "store.book-failed",
+			Timestamp:   time.Now().UTC(),
+			Data:        string(data),
+		}
+
+		go store.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+
+		storeEvent := &events.StoreBookFailedEvent{
+			AggregateID: eventSourcing.AggregateID,
+			MessageType: eventSourcing.MessageType,
+			Timestamp:   eventSourcing.Timestamp,
+			OrderID:     command.OrderID,
+			Shortages:   shortages,
 		}
+
+		go store.postgresEventHandler.StoreBookFailedEventHandler(ctx, storeEvent)
+
+		return errors.New("not enough stores")
 	}
 
+	eventsSourcing := store.bookEventsSourcing(stores)
+
 	go store.eventSourcingMongoRepository.CreateMany(ctx, eventsSourcing)
 
 	storeEvent := &events.StoreBookedEvent{
-		AggregateID: uuid.New(),
+		AggregateID: reservation.ID,
 		MessageType: eventsSourcing[0].MessageType,
 		Timestamp:   eventsSourcing[0].Timestamp,
 		OrderID:     command.OrderID,
-		Stores:      listStores,
+		Stores:      stores,
 	}
 
 	go store.postgresEventHandler.StoreBookedEventHandler(ctx, storeEvent)
@@ -177,7 +334,107 @@ func (store *StoreCommandHandler) BookStoreCommandHandler(ctx context.Context, c
 	return nil
 }
 
+func (store *StoreCommandHandler) ExtendReservationCommandHandler(ctx context.Context, command *commands.ExtendReservationCommand) (*models.Reservation, error) {
+	if command.OrderID.IsZero() {
+		return nil, errors.New("order id is required")
+	}
+
+	ttl, err := store.reservationConfig.TTL(command.TTLSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := store.reservationRepository.FindByOrder(ctx, command.OrderID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation == nil {
+		return nil, fmt.Errorf("order %s has no reservation", command.OrderID.Hex())
+	}
+
+	owner := len(reservation.CustomerID) > 0 && customerID(command.CustomerID) == reservation.CustomerID
+	if !owner && !command.Admin {
+		return nil, fmt.Errorf("order %s has no reservation", command.OrderID.Hex())
+	}
+
+	expiresAt := time.Now().UTC().Add(ttl)
+	if expiresAt.After(reservation.CreatedAt.Add(store.reservationConfig.MaxLifetime())) {
+		return nil, fmt.Errorf("reservation can not be held for more than %d seconds", store.reservationConfig.MaxLifetimeSeconds)
+	}
+
+	if !expiresAt.After(reservation.ExpiresAt) {
+		return reservation, nil
+	}
+
+	stores, err := store.reservationRepository.Extend(ctx, reservation.ID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation.ExpiresAt = expiresAt
+	reservation.Version++
+
+	data, _ := json.Marshal(reservation)
+	eventSourcing := &models.EventSourcing{
+		ID:          uuid.New(),
+		AggregateID: reservation.ID,
+		MessageType: "store.extend",
+		Timestamp:   time.Now().UTC(),
+		Data:        string(data),
+	}
+
+	go store.eventSourcingMongoRepository.Create(ctx, eventSourcing)
+
+	storeEvent := &events.StoreReservationExtendedEvent{
+		AggregateID: reservation.ID,
+		MessageType: eventSourcing.MessageType,
+		Timestamp:   eventSourcing.Timestamp,
+		OrderID:     command.OrderID,
+		ExpiresAt:   expiresAt,
+		Stores:      stores,
+	}
+
+	go store.postgresEventHandler.StoreReservationExtendedEventHandler(ctx, storeEvent)
+
+	return reservation, nil
+}
+
+func (store *StoreCommandHandler) bookEventsSourcing(stores []*models.Store) []*models.EventSourcing {
+	eventsSourcing := []*models.EventSourcing{}
+	for _, store := range stores {
+		data, _ := json.Marshal(store)
+		eventSourcing := &models.EventSourcing{
+			ID:          uuid.New(),
+			AggregateID: store.ProductID,
+			MessageType: "store.book",
+			Timestamp:   time.Now().UTC(),
+			Data:        string(data),
+		}
+		eventsSourcing = append(eventsSourcing, eventSourcing)
+	}
+
+	return eventsSourcing
+}
+
 func (store *StoreCommandHandler) UnbookStoreCommandHandler(ctx context.Context, command *commands.UnbookStoreCommand) error {
+	if !command.OrderID.IsZero() {
+		reservation, err := store.reservationRepository.FindByOrder(ctx, command.OrderID.Hex())
+		if err != nil {
+			return err
+		}
+
+		if reservation == nil {
+			return fmt.Errorf("order %s has no reservation", command.OrderID.Hex())
+		}
+
+		if !reservation.ExpiresAt.After(time.Now().UTC()) {
+			return store.releaseExpired(ctx, reservation.ID)
+		}
+
+		return store.releaseReservation(ctx, reservation.ID, models.ReservationReleased)
+	}
+
 	if command.ID == uuid.Nil {
 		return nil
 	}
@@ -196,6 +453,18 @@ func (store *StoreCommandHandler) UnbookStoreCommandHandler(ctx context.Context,
 		return err
 	}
 
+	if _store == nil {
+		return fmt.Errorf("store id: %v not found", unbookStoreDto.ID)
+	}
+
+	if _store.Sold || _store.BookedAt.After(time.Now().UTC()) {
+		return nil
+	}
+
+	if _store.ReservationID.Valid {
+		return store.releaseExpired(ctx, _store.ReservationID.UUID)
+	}
+
 	_store.BookedAt = time.Time{}
 	_store.Version++
 	_store.UpdatedAt = time.Now().UTC()
@@ -207,6 +476,38 @@ func (store *StoreCommandHandler) UnbookStoreCommandHandler(ctx context.Context,
 		return err
 	}
 
+	store.unbooked(ctx, command.ID, stores)
+
+	return nil
+}
+
+// releaseExpired marks a reservation past its expiry as expired. A reservation already released by
+// another worker is not an error.
+func (store *StoreCommandHandler) releaseExpired(ctx context.Context, ID uuid.UUID) error {
+	err := store.releaseReservation(ctx, ID, models.ReservationExpired)
+
+	var conflict *models.ConflictError
+	if errors.As(err, &conflict) {
+		return nil
+	}
+
+	return err
+}
+
+func (store *StoreCommandHandler) releaseReservation(ctx context.Context, ID uuid.UUID, status string) error {
+	stores, err := store.reservationRepository.Release(ctx, ID, status)
+	if err != nil {
+		return err
+	}
+
+	if len(stores) > 0 {
+		store.unbooked(ctx, ID, stores)
+	}
+
+	return nil
+}
+
+func (store *StoreCommandHandler) unbooked(ctx context.Context, aggregateID uuid.UUID, stores []*models.Store) {
 	eventsSourcing := []*models.EventSourcing{}
 	for _, store := range stores {
 		data, _ := json.Marshal(store)
@@ -223,22 +524,21 @@ func (store *StoreCommandHandler) UnbookStoreCommandHandler(ctx context.Context,
 	go store.eventSourcingMongoRepository.CreateMany(ctx, eventsSourcing)
 
 	storeEvent := &events.StoreUnbookedEvent{
-		AggregateID: command.ID,
+		AggregateID: aggregateID,
 		MessageType: eventsSourcing[0].MessageType,
 		Timestamp:   eventsSourcing[0].Timestamp,
 		Stores:      stores,
 	}
 
 	go store.postgresEventHandler.StoreUnbookedEventHandler(ctx, storeEvent)
-
-	return nil
 }
 
 func (store *StoreCommandHandler) PaymentStoreCommandHandler(ctx context.Context, command *commands.PaymentStoreCommand) ([]*models.Store, error) {
+	if command.OrderID.IsZero() {
+		return nil, errors.New("order id is required")
+	}
 
-	eventsSourcing := []*models.EventSourcing{}
-	listStores := []*models.Store{}
-
+	storeIDs := []uuid.UUID{}
 	for _, myStore := range command.Stores {
 		paymentStoreDto := &dtos.PaymentStore{
 			ID:   myStore.ID,
@@ -250,29 +550,24 @@ func (store *StoreCommandHandler) PaymentStoreCommandHandler(ctx context.Context
 			return nil, errors.New(strings.Join(result.([]string), ""))
 		}
 
-		_store, err := store.storePostgresRepository.FindByID(ctx, paymentStoreDto.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		if _store == nil {
-			return nil, fmt.Errorf("store id: %v not found", paymentStoreDto.ID)
-			// return errors.New(fmt.Sprintf("store id: %s not found", paymentStoreDto.ID))
-		}
+		storeIDs = append(storeIDs, paymentStoreDto.ID)
+	}
 
-		_store.BookedAt = time.Time{}
-		_store.Sold = paymentStoreDto.Sold
-		_store.Version++
-		_store.UpdatedAt = time.Now().UTC()
+	reservation, err := store.reservationRepository.FindByOrder(ctx, command.OrderID.Hex())
+	if err != nil {
+		return nil, err
+	}
 
-		listStores = append(listStores, _store)
+	if reservation == nil {
+		return nil, fmt.Errorf("order %s has no reservation", command.OrderID.Hex())
 	}
 
-	stores, err := store.storePostgresRepository.Update(ctx, listStores)
+	stores, err := store.reservationRepository.Pay(ctx, reservation.ID, storeIDs)
 	if err != nil {
 		return nil, err
 	}
 
+	eventsSourcing := []*models.EventSourcing{}
 	for _, store := range stores {
 		data, _ := json.Marshal(store)
 		eventSourcing := &models.EventSourcing{
@@ -288,7 +583,7 @@ func (store *StoreCommandHandler) PaymentStoreCommandHandler(ctx context.Context
 	go store.eventSourcingMongoRepository.CreateMany(ctx, eventsSourcing)
 
 	storeEvent := &events.StorePaidEvent{
-		AggregateID: uuid.New(),
+		AggregateID: reservation.ID,
 		MessageType: eventsSourcing[0].MessageType,
 		Timestamp:   eventsSourcing[0].Timestamp,
 		Stores:      stores,
@@ -298,3 +593,11 @@ func (store *StoreCommandHandler) PaymentStoreCommandHandler(ctx context.Context
 
 	return stores, nil
 }
+
+func customerID(ID primitive.ObjectID) string {
+	if ID.IsZero() {
+		return ""
+	}
+
+	return ID.Hex()
+}
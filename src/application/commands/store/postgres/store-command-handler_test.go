@@ -0,0 +1,131 @@
+package postgres_command
+
+import (
+	"context"
+	commands "product/src/application/commands/store"
+	postgres_event_handler "product/src/application/events/store/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"product/src/models"
+	"product/src/reservation"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reservationRepositoryStub struct {
+	repository_interface.ReservationRepository
+	reservation *models.Reservation
+	extended    bool
+}
+
+func (r *reservationRepositoryStub) FindByOrder(ctx context.Context, orderID string) (*models.Reservation, error) {
+	return r.reservation, nil
+}
+
+func (r *reservationRepositoryStub) Extend(ctx context.Context, ID uuid.UUID, expiresAt time.Time) ([]*models.Store, error) {
+	r.extended = true
+	return []*models.Store{}, nil
+}
+
+type eventSourcingRepositoryStub struct {
+	repository_interface.EventSourcingRepository
+}
+
+func (r *eventSourcingRepositoryStub) Create(ctx context.Context, eventStore *models.EventSourcing) error {
+	return nil
+}
+
+type storeTaskStub struct{}
+
+func (t *storeTaskStub) AddStore(store *models.Store) {}
+
+func (t *storeTaskStub) Run() {}
+
+type publisherStub struct{}
+
+func (p *publisherStub) Publish(subject string, data []byte) error {
+	return nil
+}
+
+func TestCustomerID(t *testing.T) {
+	objectID := primitive.NewObjectID()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"object id", `{"orderId":"` + primitive.NewObjectID().Hex() + `","customerId":"` + objectID.Hex() + `"}`, objectID.Hex()},
+		{"empty", `{"orderId":"` + primitive.NewObjectID().Hex() + `","customerId":""}`, ""},
+		{"missing", `{"orderId":"` + primitive.NewObjectID().Hex() + `"}`, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command := &commands.BookStoreCommand{}
+			if err := json.Unmarshal([]byte(test.data), command); err != nil {
+				t.Fatalf("unmarshal book command: %v", err)
+			}
+
+			if got := customerID(command.CustomerID); got != test.want {
+				t.Errorf("customerID() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestExtendReservationOwner(t *testing.T) {
+	owner := primitive.NewObjectID()
+
+	tests := []struct {
+		name       string
+		customerID string
+		command    *commands.ExtendReservationCommand
+		extended   bool
+	}{
+		{"owner", owner.Hex(), &commands.ExtendReservationCommand{CustomerID: owner}, true},
+		{"other customer", owner.Hex(), &commands.ExtendReservationCommand{CustomerID: primitive.NewObjectID()}, false},
+		{"anonymous caller", owner.Hex(), &commands.ExtendReservationCommand{}, false},
+		{"anonymous reservation", "", &commands.ExtendReservationCommand{}, false},
+		{"admin", owner.Hex(), &commands.ExtendReservationCommand{Admin: true}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			reservationRepository := &reservationRepositoryStub{
+				reservation: &models.Reservation{
+					ID:         uuid.New(),
+					OrderID:    primitive.NewObjectID().Hex(),
+					CustomerID: test.customerID,
+					Status:     models.ReservationActive,
+					ExpiresAt:  now.Add(time.Minute),
+					CreatedAt:  now,
+				},
+			}
+
+			store := NewStoreCommandHandler(nil, nil, nil, reservationRepository, &eventSourcingRepositoryStub{},
+				postgres_event_handler.NewStoreEventHandler(&storeTaskStub{}, &publisherStub{}), &publisherStub{},
+				&reservation.Config{DefaultTTLSeconds: 600, MinTTLSeconds: 60, MaxTTLSeconds: 1800, MaxLifetimeSeconds: 3600})
+
+			test.command.OrderID = primitive.NewObjectID()
+			extended, err := store.ExtendReservationCommandHandler(context.Background(), test.command)
+			if !test.extended {
+				if err == nil || reservationRepository.extended {
+					t.Errorf("ExtendReservationCommandHandler() = %+v, want error", extended)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExtendReservationCommandHandler() error: %v", err)
+			}
+			if !reservationRepository.extended || !extended.ExpiresAt.After(now.Add(time.Minute)) {
+				t.Errorf("reservation expires at %v, want it extended", extended.ExpiresAt)
+			}
+		})
+	}
+}
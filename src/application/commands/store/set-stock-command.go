@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SetStockCommand struct {
+	AggregateID uuid.UUID     `json:"aggregateId"`
+	MessageType string        `json:"messageType"`
+	Timestamp   time.Time     `json:"timestamp"`
+	ProductID   uuid.UUID     `json:"productId"`
+	VariantID   uuid.NullUUID `json:"variantId"`
+	Quantity    uint          `json:"quantity"`
+	Reason      string        `json:"reason"`
+}
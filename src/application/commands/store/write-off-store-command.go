@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WriteOffStoreCommand struct {
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	MessageType string          `json:"messageType"`
+	Timestamp   time.Time       `json:"timestamp"`
+	ProductID   uuid.UUID       `json:"productId"`
+	VariantID   uuid.NullUUID   `json:"variantId"`
+	Quantity    uint            `json:"quantity"`
+	Reason      string          `json:"reason"`
+	Stores      []*models.Store `json:"stores"`
+}
@@ -3,6 +3,7 @@ package mongo_event
 import (
 	"context"
 	"fmt"
+	"time"
 
 	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
 
@@ -30,17 +31,33 @@ func NewProductEventHandler(
 func (product *ProductEventHandler) ProductCreatedEventHandler(event *events.ProductCreatedEvent) error {
 
 	_product := &models.Product{
-		ID:          event.ID,
-		Name:        event.Name,
-		Slug:        event.Slug,
-		Description: event.Description,
-		Price:       event.Price,
-		Quantity:    event.Quantity,
-		Image:       event.Image,
-		CreatedAt:   event.CreatedAt,
-		UpdatedAt:   event.UpdatedAt,
-		Version:     event.Version,
-		Deleted:     event.Deleted,
+		ID:           event.ID,
+		Name:         event.Name,
+		Slug:         event.Slug,
+		Description:  event.Description,
+		Price:        event.Price,
+		Quantity:     event.Quantity,
+		Image:        event.Image,
+		Categories:   event.Categories,
+		BrandID:      event.BrandID,
+		Options:      event.Options,
+		Variants:     event.Variants,
+		Prices:       event.Prices,
+		Promotions:   event.Promotions,
+		Status:       event.Status,
+		PublishAt:    event.PublishAt,
+		Translations: event.Translations,
+		Attributes:   event.Attributes,
+		Type:         event.Type,
+		Components:   event.Components,
+		Relations:    event.Relations,
+		Rating:       event.Rating,
+		ReviewCount:  event.ReviewCount,
+		SalePrice:    models.SalePrice(event.Promotions, time.Now().UTC()),
+		CreatedAt:    event.CreatedAt,
+		UpdatedAt:    event.UpdatedAt,
+		Version:      event.Version,
+		Deleted:      event.Deleted,
 	}
 
 	ctx := context.Background()
@@ -57,15 +74,31 @@ func (product *ProductEventHandler) ProductCreatedEventHandler(event *events.Pro
 func (product *ProductEventHandler) ProductUpdatedEventHandler(event *events.ProductUpdatedEvent) error {
 
 	_product := &models.Product{
-		ID:          event.ID,
-		Name:        event.Name,
-		Slug:        event.Slug,
-		Description: event.Description,
-		Price:       event.Price,
-		Quantity:    event.Quantity,
-		Image:       event.Image,
-		UpdatedAt:   event.UpdatedAt,
-		Version:     event.Version,
+		ID:           event.ID,
+		Name:         event.Name,
+		Slug:         event.Slug,
+		Description:  event.Description,
+		Price:        event.Price,
+		Quantity:     event.Quantity,
+		Image:        event.Image,
+		Categories:   event.Categories,
+		BrandID:      event.BrandID,
+		Options:      event.Options,
+		Variants:     event.Variants,
+		Prices:       event.Prices,
+		Promotions:   event.Promotions,
+		Status:       event.Status,
+		PublishAt:    event.PublishAt,
+		Translations: event.Translations,
+		Attributes:   event.Attributes,
+		Type:         event.Type,
+		Components:   event.Components,
+		Relations:    event.Relations,
+		Rating:       event.Rating,
+		ReviewCount:  event.ReviewCount,
+		SalePrice:    models.SalePrice(event.Promotions, time.Now().UTC()),
+		UpdatedAt:    event.UpdatedAt,
+		Version:      event.Version,
 	}
 
 	ctx := context.Background()
@@ -78,3 +111,15 @@ func (product *ProductEventHandler) ProductUpdatedEventHandler(event *events.Pro
 
 	return nil
 }
+
+func (product *ProductEventHandler) ProductDeletedEventHandler(event *events.ProductDeletedEvent) error {
+	ctx := context.Background()
+	err := product.productRedisRepository.Delete(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("product deleted redis successfully!")
+
+	return nil
+}
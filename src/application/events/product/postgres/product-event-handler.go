@@ -8,9 +8,12 @@ import (
 
 	commandProduct "product/src/application/commands/product"
 	commandStore "product/src/application/commands/store"
+	"product/src/models"
 	"product/src/nats/subjects"
 
 	events "product/src/application/events/product"
+
+	"github.com/google/uuid"
 )
 
 type ProductEventHandler struct {
@@ -26,19 +29,29 @@ func NewProductEventHandler(
 }
 
 func (product *ProductEventHandler) ProductCreatedEventHandler(ctx context.Context, event *events.ProductCreatedEvent) error {
-	createStorePostgresCommand := &commandStore.CreateStoreCommand{
-		ProductID: event.ID,
-		Quantity:  event.Quantity,
-	}
+	switch {
+	case event.Type == models.ProductBundle:
+		// bundle availability comes from the stores of its components
+	case len(event.Variants) > 0:
+		err := product.createVariantStores(event.ID, event.Variants)
+		if err != nil {
+			return err
+		}
+	default:
+		createStorePostgresCommand := &commandStore.CreateStoreCommand{
+			ProductID: event.ID,
+			Quantity:  event.Quantity,
+		}
 
-	dataCommand, _ := json.Marshal(createStorePostgresCommand)
-	err := product.publisher.Publish(string(subjects.StoreCreatePostgres), dataCommand)
-	if err != nil {
-		return err
+		dataCommand, _ := json.Marshal(createStorePostgresCommand)
+		err := product.publisher.Publish(string(subjects.StoreCreatePostgres), dataCommand)
+		if err != nil {
+			return err
+		}
 	}
 
 	dataEvent, _ := json.Marshal(event)
-	err = product.publisher.Publish(string(subjects.ProductCreateMongo), dataEvent)
+	err := product.publisher.Publish(string(subjects.ProductCreateMongo), dataEvent)
 	if err != nil {
 		return err
 	}
@@ -48,13 +61,26 @@ func (product *ProductEventHandler) ProductCreatedEventHandler(ctx context.Conte
 
 func (product *ProductEventHandler) ProductUpdatedEventHandler(ctx context.Context, event *events.ProductUpdatedEvent) error {
 	updateProductMongoCommand := &commandProduct.UpdateProductCommand{
-		ID:          event.ID,
-		Name:        event.Name,
-		Slug:        event.Slug,
-		Description: event.Description,
-		Price:       event.Price,
-		Image:       event.Image,
-		Version:     event.Version,
+		ID:           event.ID,
+		Name:         event.Name,
+		Slug:         event.Slug,
+		Description:  event.Description,
+		Price:        event.Price,
+		Image:        event.Image,
+		Categories:   event.Categories,
+		BrandID:      event.BrandID,
+		Options:      event.Options,
+		Variants:     event.Variants,
+		Prices:       event.Prices,
+		Promotions:   event.Promotions,
+		Status:       event.Status,
+		PublishAt:    event.PublishAt,
+		Translations: event.Translations,
+		Attributes:   event.Attributes,
+		Type:         event.Type,
+		Components:   event.Components,
+		Relations:    event.Relations,
+		Version:      event.Version,
 	}
 
 	dataCommand, _ := json.Marshal(updateProductMongoCommand)
@@ -63,5 +89,87 @@ func (product *ProductEventHandler) ProductUpdatedEventHandler(ctx context.Conte
 		return err
 	}
 
+	return product.createVariantStores(event.ID, event.Variants)
+}
+
+func (product *ProductEventHandler) ProductPatchedEventHandler(ctx context.Context, event *events.ProductPatchedEvent) error {
+	patchProductMongoCommand := &commandProduct.PatchProductCommand{
+		AggregateID: event.AggregateID,
+		MessageType: event.MessageType,
+		Timestamp:   event.Timestamp,
+		ID:          event.ID,
+		Patch:       event.Patch,
+	}
+
+	dataCommand, _ := json.Marshal(patchProductMongoCommand)
+	err := product.publisher.Publish(string(subjects.ProductPatchMongo), dataCommand)
+	if err != nil {
+		return err
+	}
+
+	changes := struct {
+		Variants []*models.ProductVariant `json:"variants"`
+	}{}
+	err = json.Unmarshal(event.Patch, &changes)
+	if err != nil {
+		return err
+	}
+
+	return product.createVariantStores(event.ID, changes.Variants)
+}
+
+func (product *ProductEventHandler) ProductDeletedEventHandler(ctx context.Context, event *events.ProductDeletedEvent) error {
+	deleteProductMongoCommand := &commandProduct.DeleteProductCommand{
+		AggregateID: event.AggregateID,
+		MessageType: event.MessageType,
+		Timestamp:   event.Timestamp,
+		ID:          event.ID,
+	}
+
+	dataCommand, _ := json.Marshal(deleteProductMongoCommand)
+	err := product.publisher.Publish(string(subjects.ProductDeleteMongo), dataCommand)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (product *ProductEventHandler) ProductRestoredEventHandler(ctx context.Context, event *events.ProductRestoredEvent) error {
+	restoreProductMongoCommand := &commandProduct.RestoreProductCommand{
+		AggregateID: event.AggregateID,
+		MessageType: event.MessageType,
+		Timestamp:   event.Timestamp,
+		ID:          event.ID,
+	}
+
+	dataCommand, _ := json.Marshal(restoreProductMongoCommand)
+	err := product.publisher.Publish(string(subjects.ProductRestoreMongo), dataCommand)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (product *ProductEventHandler) createVariantStores(productID uuid.UUID, variants []*models.ProductVariant) error {
+	for _, variant := range variants {
+		if variant.Quantity == 0 {
+			continue
+		}
+
+		createStorePostgresCommand := &commandStore.CreateStoreCommand{
+			ProductID: productID,
+			VariantID: uuid.NullUUID{UUID: variant.ID, Valid: true},
+			Quantity:  variant.Quantity,
+		}
+
+		dataCommand, _ := json.Marshal(createStorePostgresCommand)
+		err := product.publisher.Publish(string(subjects.StoreCreatePostgres), dataCommand)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
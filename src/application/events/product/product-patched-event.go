@@ -0,0 +1,16 @@
+package postgres_event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductPatchedEvent struct {
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	MessageType string          `json:"messageType"`
+	Timestamp   time.Time       `json:"timestamp"`
+	ID          uuid.UUID       `json:"id"`
+	Patch       json.RawMessage `json:"patch"`
+}
@@ -0,0 +1,18 @@
+package postgres_event
+
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductPriceChangedEvent struct {
+	AggregateID uuid.UUID     `json:"aggregateId"`
+	MessageType string        `json:"messageType"`
+	Timestamp   time.Time     `json:"timestamp"`
+	ID          uuid.UUID     `json:"id"`
+	Price       models.Money  `json:"price"`
+	SalePrice   *models.Money `json:"sale_price,omitempty"`
+	PromotionID uuid.NullUUID `json:"promotionId"`
+}
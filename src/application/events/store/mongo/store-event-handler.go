@@ -37,3 +37,10 @@ func (store *StoreEventHandler) StorePaidEventHandler(event *events.StorePaidEve
 
 	return nil
 }
+
+func (store *StoreEventHandler) StoreWrittenOffEventHandler(event *events.StoreWrittenOffEvent) error {
+
+	//fmt.Println(event)
+
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	common_models "github.com/JohnSalazar/microservices-go-common/models"
 	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
 
 	command "product/src/application/commands/store"
@@ -100,6 +102,49 @@ func (store *StoreEventHandler) StoreBookedEventHandler(ctx context.Context, eve
 	return nil
 }
 
+func (store *StoreEventHandler) StoreBookFailedEventHandler(ctx context.Context, event *events.StoreBookFailedEvent) error {
+	updateStatusOrder := &dtos.UpdateStatusOrder{
+		ID:       event.OrderID,
+		Status:   uint(common_models.OrderCanceled),
+		StatusAt: time.Now().UTC(),
+	}
+	dataOrder, _ := json.Marshal(updateStatusOrder)
+	err := store.publisher.Publish(string(common_nats.OrderStatus), dataOrder)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	data, _ := json.Marshal(event)
+	err = store.publisher.Publish(string(subjects.StoreBookFailed), data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (store *StoreEventHandler) StoreReservationExtendedEventHandler(ctx context.Context, event *events.StoreReservationExtendedEvent) error {
+	bookStoreCommand := &command.BookStoreCommand{
+		AggregateID: event.AggregateID,
+		MessageType: event.MessageType,
+		Timestamp:   event.Timestamp,
+		OrderID:     event.OrderID,
+		Stores:      event.Stores,
+	}
+
+	for _, _store := range event.Stores {
+		store.storeTask.AddStore(_store)
+	}
+
+	data, _ := json.Marshal(bookStoreCommand)
+	err := store.publisher.Publish(string(subjects.StoreBookMongo), data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (store *StoreEventHandler) StoreUnbookedEventHandler(ctx context.Context, event *events.StoreUnbookedEvent) error {
 	// unbookStoreCommands := []*command.UnbookStoreCommand{}
 	// for _, _store := range event.Stores {
@@ -137,3 +182,23 @@ func (store *StoreEventHandler) StorePaidEventHandler(ctx context.Context, event
 
 	return nil
 }
+
+func (store *StoreEventHandler) StoreWrittenOffEventHandler(ctx context.Context, event *events.StoreWrittenOffEvent) error {
+	writeOffStoreCommand := &command.WriteOffStoreCommand{
+		AggregateID: event.AggregateID,
+		MessageType: event.MessageType,
+		Timestamp:   event.Timestamp,
+		ProductID:   event.ProductID,
+		Quantity:    uint(len(event.Stores)),
+		Reason:      event.Reason,
+		Stores:      event.Stores,
+	}
+
+	data, _ := json.Marshal(writeOffStoreCommand)
+	err := store.publisher.Publish(string(subjects.StoreWriteOffMongo), data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
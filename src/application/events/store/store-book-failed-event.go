@@ -0,0 +1,17 @@
+package events
+
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StoreBookFailedEvent struct {
+	AggregateID uuid.UUID              `json:"aggregateId"`
+	MessageType string                 `json:"messageType"`
+	Timestamp   time.Time              `json:"timestamp"`
+	OrderID     primitive.ObjectID     `json:"orderId"`
+	Shortages   []*models.StoreBooking `json:"shortages"`
+}
@@ -0,0 +1,18 @@
+package events
+
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StoreReservationExtendedEvent struct {
+	AggregateID uuid.UUID          `json:"aggregateId"`
+	MessageType string             `json:"messageType"`
+	Timestamp   time.Time          `json:"timestamp"`
+	OrderID     primitive.ObjectID `json:"orderId"`
+	ExpiresAt   time.Time          `json:"expiresAt"`
+	Stores      []*models.Store    `json:"stores"`
+}
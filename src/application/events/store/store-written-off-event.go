@@ -0,0 +1,17 @@
+package events
+
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StoreWrittenOffEvent struct {
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	MessageType string          `json:"messageType"`
+	Timestamp   time.Time       `json:"timestamp"`
+	ProductID   uuid.UUID       `json:"productId"`
+	Reason      string          `json:"reason"`
+	Stores      []*models.Store `json:"stores"`
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+	command_attribute "product/src/application/commands/attribute"
+	postgres_attribute_command_handler "product/src/application/commands/attribute/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"strings"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttributeController struct {
+	attributePostgresRepository     repository_interface.AttributeRepository
+	attributePostgresCommandHandler *postgres_attribute_command_handler.AttributeCommandHandler
+}
+
+func NewAttributeController(
+	attributePostgresRepository repository_interface.AttributeRepository,
+	attributePostgresCommandHandler *postgres_attribute_command_handler.AttributeCommandHandler,
+) *AttributeController {
+	return &AttributeController{
+		attributePostgresRepository:     attributePostgresRepository,
+		attributePostgresCommandHandler: attributePostgresCommandHandler,
+	}
+}
+
+func (attribute *AttributeController) GetAll(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "AttributeController.GetAll")
+	defer span.End()
+
+	attributes, err := attribute.attributePostgresRepository.GetAll(c.Request.Context())
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "attributes get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, attributes)
+}
+
+func (attribute *AttributeController) GetAttributeById(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "AttributeController.GetAttributeById")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	_attribute, err := attribute.attributePostgresRepository.FindByID(c.Request.Context(), ID)
+	if _attribute == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "attribute not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, _attribute)
+}
+
+func (attribute *AttributeController) GetAttributeByCode(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "AttributeController.GetAttributeByCode")
+	defer span.End()
+
+	code := c.Param("code")
+	if strings.TrimSpace(code) == "" {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid code")
+		return
+	}
+
+	_attribute, err := attribute.attributePostgresRepository.FindByCode(c.Request.Context(), code)
+	if _attribute == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "attribute not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, _attribute)
+}
+
+func (attribute *AttributeController) AddAttribute(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "AttributeController.AddAttribute")
+	defer span.End()
+
+	createAttributeCommand := &command_attribute.CreateAttributeCommand{}
+	err := c.BindJSON(createAttributeCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if createAttributeCommand.ID == uuid.Nil {
+		createAttributeCommand.ID = uuid.New()
+	}
+
+	attributeModel, err := attribute.attributePostgresCommandHandler.CreateAttributeCommandHandler(ctx, createAttributeCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, attributeModel)
+}
+
+func (attribute *AttributeController) UpdateAttribute(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "AttributeController.UpdateAttribute")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid attribute id")
+		return
+	}
+
+	updateAttributeCommand := &command_attribute.UpdateAttributeCommand{}
+	err = c.BindJSON(updateAttributeCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if updateAttributeCommand.ID != ID {
+		trace.FailSpan(span, "Error divergent attribute id")
+		httputil.NewResponseError(c, http.StatusBadRequest, "Error divergent attribute id")
+		return
+	}
+
+	attributeModel, err := attribute.attributePostgresCommandHandler.UpdateAttributeCommandHandler(ctx, updateAttributeCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, attributeModel)
+}
+
+func (attribute *AttributeController) DeleteAttribute(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "AttributeController.DeleteAttribute")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid attribute id")
+		return
+	}
+
+	deleteAttributeCommand := &command_attribute.DeleteAttributeCommand{
+		ID: ID,
+	}
+
+	err = attribute.attributePostgresCommandHandler.DeleteAttributeCommandHandler(ctx, deleteAttributeCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "attribute deleted")
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+	command_brand "product/src/application/commands/brand"
+	postgres_brand_command_handler "product/src/application/commands/brand/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"strings"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BrandController struct {
+	brandPostgresRepository     repository_interface.BrandRepository
+	brandPostgresCommandHandler *postgres_brand_command_handler.BrandCommandHandler
+}
+
+func NewBrandController(
+	brandPostgresRepository repository_interface.BrandRepository,
+	brandPostgresCommandHandler *postgres_brand_command_handler.BrandCommandHandler,
+) *BrandController {
+	return &BrandController{
+		brandPostgresRepository:     brandPostgresRepository,
+		brandPostgresCommandHandler: brandPostgresCommandHandler,
+	}
+}
+
+func (brand *BrandController) GetAll(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "BrandController.GetAll")
+	defer span.End()
+
+	brands, err := brand.brandPostgresRepository.GetAll(c.Request.Context())
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "brands get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, brands)
+}
+
+func (brand *BrandController) GetBrandById(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "BrandController.GetBrandById")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	_brand, err := brand.brandPostgresRepository.FindByID(c.Request.Context(), ID)
+	if _brand == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "brand not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, _brand)
+}
+
+func (brand *BrandController) GetBrandBySlug(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "BrandController.GetBrandBySlug")
+	defer span.End()
+
+	slug := c.Param("slug")
+	if strings.TrimSpace(slug) == "" {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid slug")
+		return
+	}
+
+	_brand, err := brand.brandPostgresRepository.FindBySlug(c.Request.Context(), slug)
+	if _brand == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "brand not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, _brand)
+}
+
+func (brand *BrandController) AddBrand(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "BrandController.AddBrand")
+	defer span.End()
+
+	createBrandCommand := &command_brand.CreateBrandCommand{}
+	err := c.BindJSON(createBrandCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if createBrandCommand.ID == uuid.Nil {
+		createBrandCommand.ID = uuid.New()
+	}
+
+	brandModel, err := brand.brandPostgresCommandHandler.CreateBrandCommandHandler(ctx, createBrandCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, brandModel)
+}
+
+func (brand *BrandController) UpdateBrand(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "BrandController.UpdateBrand")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid brand id")
+		return
+	}
+
+	updateBrandCommand := &command_brand.UpdateBrandCommand{}
+	err = c.BindJSON(updateBrandCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if updateBrandCommand.ID != ID {
+		trace.FailSpan(span, "Error divergent brand id")
+		httputil.NewResponseError(c, http.StatusBadRequest, "Error divergent brand id")
+		return
+	}
+
+	brandModel, err := brand.brandPostgresCommandHandler.UpdateBrandCommandHandler(ctx, updateBrandCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, brandModel)
+}
+
+func (brand *BrandController) DeleteBrand(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "BrandController.DeleteBrand")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid brand id")
+		return
+	}
+
+	deleteBrandCommand := &command_brand.DeleteBrandCommand{
+		ID: ID,
+	}
+
+	err = brand.brandPostgresCommandHandler.DeleteBrandCommandHandler(ctx, deleteBrandCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "brand deleted")
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"product/src/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const productCacheControl = "public, max-age=60, must-revalidate"
+
+// Stock, promotions and ratings change without a new product version, so they are part of the fingerprint.
+func writeProductFingerprint(h hash.Hash, product *models.Product) {
+	fmt.Fprintf(h, "%s|%d|%d|%d|%s|%s|%s|%v|%d|",
+		product.ID,
+		product.Version,
+		product.UpdatedAt.UnixNano(),
+		product.Quantity,
+		product.Price.Amount.String(),
+		product.Price.Currency,
+		product.Name,
+		product.Rating,
+		product.ReviewCount)
+
+	if product.SalePrice != nil {
+		fmt.Fprintf(h, "%s|", product.SalePrice.Amount.String())
+	}
+
+	for _, variant := range product.Variants {
+		fmt.Fprintf(h, "%s|%d|", variant.ID, variant.Quantity)
+		if variant.Price != nil {
+			fmt.Fprintf(h, "%s|", variant.Price.Amount.String())
+		}
+	}
+}
+
+func fingerprint(c *gin.Context, locale string, products ...*models.Product) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%s|", c.Request.URL.Path, c.Request.URL.RawQuery)
+	fmt.Fprintf(h, "%s|", locale)
+	for _, product := range products {
+		writeProductFingerprint(h, product)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func productETag(c *gin.Context, locale string, product *models.Product) string {
+	return fmt.Sprintf(`"%d-%s"`, product.Version, fingerprint(c, locale, product))
+}
+
+func listETag(c *gin.Context, locale string, products []*models.Product) string {
+	return fmt.Sprintf(`W/"%s"`, fingerprint(c, locale, products...))
+}
+
+// lastModified is the latest of the product timestamps, the promotion windows that have opened or closed
+// and stockChangedAt, so that availability and sale prices move it forward as well.
+func lastModified(stockChangedAt time.Time, products ...*models.Product) time.Time {
+	now := time.Now().UTC()
+	modified := stockChangedAt
+	latest := func(at time.Time) {
+		if at.After(modified) && !at.After(now) {
+			modified = at
+		}
+	}
+
+	for _, product := range products {
+		latest(product.UpdatedAt)
+		for _, variant := range product.Variants {
+			latest(variant.UpdatedAt)
+		}
+		for _, promotion := range product.Promotions {
+			latest(promotion.StartsAt)
+			latest(promotion.EndsAt)
+		}
+	}
+
+	return modified.UTC().Truncate(time.Second)
+}
+
+// notModified writes the caching headers and answers 304 when the validators still match. If-Modified-Since
+// is only consulted when If-None-Match is absent (RFC 7232 section 6).
+func notModified(c *gin.Context, etag string, modified time.Time) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", productCacheControl)
+	c.Header("Vary", "Accept-Language")
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := strings.TrimSpace(c.GetHeader("If-None-Match")); len(ifNoneMatch) > 0 {
+		if !etagMatch(ifNoneMatch, etag) {
+			return false
+		}
+	} else if !unmodifiedSince(c.GetHeader("If-Modified-Since"), modified) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+func unmodifiedSince(header string, modified time.Time) bool {
+	if modified.IsZero() || len(strings.TrimSpace(header)) == 0 {
+		return false
+	}
+
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+func etagMatch(header string, etag string) bool {
+	if header == "*" {
+		return true
+	}
+
+	current := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+			return true
+		}
+	}
+
+	return false
+}
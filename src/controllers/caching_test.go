@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+	"product/src/models"
+	"testing"
+	"time"
+)
+
+func TestEtagMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		want   bool
+	}{
+		{"any", "*", `"3-abc"`, true},
+		{"strong match", `"3-abc"`, `"3-abc"`, true},
+		{"weak header matches strong tag", `W/"3-abc"`, `"3-abc"`, true},
+		{"strong header matches weak tag", `"abc"`, `W/"abc"`, true},
+		{"list with match", `"2-abc", "3-abc"`, `"3-abc"`, true},
+		{"mismatch", `"2-abc"`, `"3-abc"`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := etagMatch(test.header, test.etag); got != test.want {
+				t.Errorf("etagMatch(%q, %q) = %v, want %v", test.header, test.etag, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		modified time.Time
+		want     bool
+	}{
+		{"no validators", map[string]string{}, modified, false},
+		{"matching etag", map[string]string{"If-None-Match": `"3-abc"`}, modified, true},
+		{"stale etag", map[string]string{"If-None-Match": `"2-abc"`}, modified, false},
+		{"modified since", map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, modified, false},
+		{"not modified since", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, modified, true},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, modified, false},
+		{"unknown last modified", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, time.Time{}, false},
+		{"stale etag wins over date", map[string]string{"If-None-Match": `"2-abc"`, "If-Modified-Since": modified.Format(http.TimeFormat)}, modified, false},
+		{"matching etag wins over date", map[string]string{"If-None-Match": `"3-abc"`, "If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)}, modified, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, recorder := testContext(test.headers)
+			if got := notModified(c, `"3-abc"`, test.modified); got != test.want {
+				t.Errorf("notModified() = %v, want %v", got, test.want)
+			}
+
+			if got := recorder.Header().Get("ETag"); got != `"3-abc"` {
+				t.Errorf("ETag header = %s, want %s", got, `"3-abc"`)
+			}
+
+			want := ""
+			if !test.modified.IsZero() {
+				want = test.modified.Format(http.TimeFormat)
+			}
+			if got := recorder.Header().Get("Last-Modified"); got != want {
+				t.Errorf("Last-Modified header = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 1, 12, 0, 0, 500, time.UTC)
+	later := updatedAt.Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		stockChangedAt time.Time
+		product        *models.Product
+		want           time.Time
+	}{
+		{"product", time.Time{}, &models.Product{UpdatedAt: updatedAt}, updatedAt.Truncate(time.Second)},
+		{"stock change", later, &models.Product{UpdatedAt: updatedAt}, later.Truncate(time.Second)},
+		{"variant", time.Time{}, &models.Product{UpdatedAt: updatedAt, Variants: []*models.ProductVariant{{UpdatedAt: later}}}, later.Truncate(time.Second)},
+		{"promotion started", time.Time{}, &models.Product{UpdatedAt: updatedAt, Promotions: []*models.ProductPromotion{{StartsAt: later, EndsAt: time.Now().Add(time.Hour)}}}, later.Truncate(time.Second)},
+		{"promotion ended", time.Time{}, &models.Product{UpdatedAt: updatedAt, Promotions: []*models.ProductPromotion{{StartsAt: updatedAt.Add(-time.Hour), EndsAt: later}}}, later.Truncate(time.Second)},
+		{"promotion not started", time.Time{}, &models.Product{UpdatedAt: updatedAt, Promotions: []*models.ProductPromotion{{StartsAt: time.Now().Add(time.Hour), EndsAt: time.Now().Add(2 * time.Hour)}}}, updatedAt.Truncate(time.Second)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lastModified(test.stockChangedAt, test.product); !got.Equal(test.want) {
+				t.Errorf("lastModified() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"net/http"
+	command_category "product/src/application/commands/category"
+	postgres_category_command_handler "product/src/application/commands/category/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"product/src/models"
+	"strings"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategoryController struct {
+	categoryPostgresRepository     repository_interface.CategoryRepository
+	categoryPostgresCommandHandler *postgres_category_command_handler.CategoryCommandHandler
+}
+
+func NewCategoryController(
+	categoryPostgresRepository repository_interface.CategoryRepository,
+	categoryPostgresCommandHandler *postgres_category_command_handler.CategoryCommandHandler,
+) *CategoryController {
+	return &CategoryController{
+		categoryPostgresRepository:     categoryPostgresRepository,
+		categoryPostgresCommandHandler: categoryPostgresCommandHandler,
+	}
+}
+
+func (category *CategoryController) GetAll(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "CategoryController.GetAll")
+	defer span.End()
+
+	categories, err := category.categoryPostgresRepository.GetAll(c.Request.Context())
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "categories get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func (category *CategoryController) GetTree(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "CategoryController.GetTree")
+	defer span.End()
+
+	categories, err := category.categoryPostgresRepository.GetAll(c.Request.Context())
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "categories get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, category.buildTree(categories))
+}
+
+func (category *CategoryController) GetCategoryById(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "CategoryController.GetCategoryById")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	_category, err := category.categoryPostgresRepository.FindByID(c.Request.Context(), ID)
+	if _category == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "category not found")
+		return
+	}
+
+	_category.Children, err = category.categoryPostgresRepository.GetChildren(c.Request.Context(), _category.ID)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "categories get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, _category)
+}
+
+func (category *CategoryController) GetCategoryBySlug(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "CategoryController.GetCategoryBySlug")
+	defer span.End()
+
+	slug := c.Param("slug")
+	if strings.TrimSpace(slug) == "" {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid slug")
+		return
+	}
+
+	_category, err := category.categoryPostgresRepository.FindBySlug(c.Request.Context(), slug)
+	if _category == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "category not found")
+		return
+	}
+
+	_category.Children, err = category.categoryPostgresRepository.GetChildren(c.Request.Context(), _category.ID)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "categories get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, _category)
+}
+
+func (category *CategoryController) AddCategory(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "CategoryController.AddCategory")
+	defer span.End()
+
+	createCategoryCommand := &command_category.CreateCategoryCommand{}
+	err := c.BindJSON(createCategoryCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if createCategoryCommand.ID == uuid.Nil {
+		createCategoryCommand.ID = uuid.New()
+	}
+
+	categoryModel, err := category.categoryPostgresCommandHandler.CreateCategoryCommandHandler(ctx, createCategoryCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoryModel)
+}
+
+func (category *CategoryController) UpdateCategory(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "CategoryController.UpdateCategory")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid category id")
+		return
+	}
+
+	updateCategoryCommand := &command_category.UpdateCategoryCommand{}
+	err = c.BindJSON(updateCategoryCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if updateCategoryCommand.ID != ID {
+		trace.FailSpan(span, "Error divergent category id")
+		httputil.NewResponseError(c, http.StatusBadRequest, "Error divergent category id")
+		return
+	}
+
+	categoryModel, err := category.categoryPostgresCommandHandler.UpdateCategoryCommandHandler(ctx, updateCategoryCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryModel)
+}
+
+func (category *CategoryController) DeleteCategory(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "CategoryController.DeleteCategory")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid category id")
+		return
+	}
+
+	deleteCategoryCommand := &command_category.DeleteCategoryCommand{
+		ID: ID,
+	}
+
+	err = category.categoryPostgresCommandHandler.DeleteCategoryCommandHandler(ctx, deleteCategoryCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "category deleted")
+}
+
+func (category *CategoryController) buildTree(categories []*models.Category) []*models.Category {
+	nodes := map[uuid.UUID]*models.Category{}
+	for _, _category := range categories {
+		nodes[_category.ID] = _category
+	}
+
+	tree := []*models.Category{}
+	for _, _category := range categories {
+		parent, ok := nodes[_category.ParentID.UUID]
+		if _category.ParentID.Valid && ok {
+			parent.Children = append(parent.Children, _category)
+			continue
+		}
+
+		tree = append(tree, _category)
+	}
+
+	return tree
+}
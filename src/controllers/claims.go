@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hasClaim reports whether the authenticated user holds every value of the claim, as the common Authorization
+// middleware checks it, for handlers that only need the claim to widen what the caller may do.
+func hasClaim(c *gin.Context, claimType string, claimValue string) bool {
+	value, ok := c.Get("claims")
+	if !ok {
+		return false
+	}
+
+	claims, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, item := range claims {
+		claim, ok := item.(map[string]interface{})
+		if !ok || claim["type"] != claimType {
+			continue
+		}
+
+		values, _ := claim["value"].(string)
+		granted := strings.Split(values, ",")
+		for _, wanted := range strings.Split(claimValue, ",") {
+			if !containsString(granted, wanted) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
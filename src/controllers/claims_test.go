@@ -0,0 +1,29 @@
+package controllers
+
+import "testing"
+
+func TestHasClaim(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims interface{}
+		want   bool
+	}{
+		{"no claims", nil, false},
+		{"admin update", []interface{}{map[string]interface{}{"type": "admin", "value": "create,update"}}, true},
+		{"admin read only", []interface{}{map[string]interface{}{"type": "admin", "value": "read"}}, false},
+		{"other claim", []interface{}{map[string]interface{}{"type": "product", "value": "update"}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := testContext(nil)
+			if test.claims != nil {
+				c.Set("claims", test.claims)
+			}
+
+			if got := hasClaim(c, "admin", "update"); got != test.want {
+				t.Errorf("hasClaim() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
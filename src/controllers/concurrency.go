@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"product/src/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func etag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", etag(version))
+}
+
+// ifMatch accepts the version tag and the representation tags served on GET, which start with the version.
+func ifMatch(c *gin.Context, version uint) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if len(header) == 0 || header == "*" {
+		return true
+	}
+
+	current := etag(version)
+	representation := fmt.Sprintf(`"%d-`, version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == current || strings.HasPrefix(tag, representation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func errorStatus(err error) int {
+	var conflict *models.ConflictError
+	if errors.As(err, &conflict) {
+		return http.StatusConflict
+	}
+
+	var notFound *models.NotFoundError
+	if errors.As(err, &notFound) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"product/src/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func testContext(headers map[string]string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	for key, value := range headers {
+		c.Request.Header.Set(key, value)
+	}
+
+	return c, recorder
+}
+
+func TestETag(t *testing.T) {
+	if got := etag(7); got != `"7"` {
+		t.Errorf("etag(7) = %s, want %s", got, `"7"`)
+	}
+
+	c, recorder := testContext(nil)
+	setETag(c, 7)
+	if got := recorder.Header().Get("ETag"); got != `"7"` {
+		t.Errorf("ETag header = %s, want %s", got, `"7"`)
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version uint
+		want    bool
+	}{
+		{"no header", "", 3, true},
+		{"any", "*", 3, true},
+		{"any with spaces", " * ", 3, true},
+		{"strong match", `"3"`, 3, true},
+		{"strong mismatch", `"2"`, 3, false},
+		{"version prefix is not a match", `"31"`, 3, false},
+		{"weak tag never matches", `W/"3"`, 3, false},
+		{"list with match", `"1", "3"`, 3, true},
+		{"list without match", `"1", "2"`, 3, false},
+		{"representation tag", `"3-0a1b2c3d4e5f6a7b"`, 3, true},
+		{"representation tag of another version", `"31-0a1b2c3d4e5f6a7b"`, 3, false},
+		{"weak representation tag", `W/"3-0a1b2c3d4e5f6a7b"`, 3, false},
+		{"unquoted", `3`, 3, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if len(test.header) > 0 {
+				headers["If-Match"] = test.header
+			}
+
+			c, _ := testContext(headers)
+			if got := ifMatch(c, test.version); got != test.want {
+				t.Errorf("ifMatch(%q, %d) = %v, want %v", test.header, test.version, got, test.want)
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"conflict", models.NewConflictError("conflict"), http.StatusConflict},
+		{"wrapped conflict", fmt.Errorf("update: %w", models.NewConflictError("conflict")), http.StatusConflict},
+		{"not found", models.NewNotFoundError("not found"), http.StatusNotFound},
+		{"other", errors.New("invalid"), http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := errorStatus(test.err); got != test.want {
+				t.Errorf("errorStatus(%v) = %d, want %d", test.err, got, test.want)
+			}
+		})
+	}
+}
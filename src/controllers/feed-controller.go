@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"net/http"
+	"product/src/feed"
+	"time"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+)
+
+const xmlContentType = "application/xml; charset=utf-8"
+
+type FeedController struct {
+	feed *feed.Feed
+}
+
+func NewFeedController(
+	feed *feed.Feed,
+) *FeedController {
+	return &FeedController{
+		feed: feed,
+	}
+}
+
+func (feed *FeedController) GetGoogleFeed(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "FeedController.GetGoogleFeed")
+	defer span.End()
+
+	data, generatedAt := feed.feed.Google()
+	feed.write(c, data, generatedAt)
+}
+
+func (feed *FeedController) GetSitemap(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "FeedController.GetSitemap")
+	defer span.End()
+
+	data, generatedAt := feed.feed.Sitemap()
+	feed.write(c, data, generatedAt)
+}
+
+func (feed *FeedController) write(c *gin.Context, data []byte, generatedAt time.Time) {
+	if len(data) == 0 {
+		httputil.NewResponseError(c, http.StatusServiceUnavailable, "feed not generated yet")
+		return
+	}
+
+	c.Header("Last-Modified", generatedAt.Format(http.TimeFormat))
+	c.Data(http.StatusOK, xmlContentType, data)
+}
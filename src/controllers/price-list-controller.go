@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"net/http"
+	command_price_list "product/src/application/commands/pricelist"
+	postgres_price_list_command_handler "product/src/application/commands/pricelist/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PriceListController struct {
+	priceListPostgresRepository     repository_interface.PriceListRepository
+	priceListPostgresCommandHandler *postgres_price_list_command_handler.PriceListCommandHandler
+}
+
+func NewPriceListController(
+	priceListPostgresRepository repository_interface.PriceListRepository,
+	priceListPostgresCommandHandler *postgres_price_list_command_handler.PriceListCommandHandler,
+) *PriceListController {
+	return &PriceListController{
+		priceListPostgresRepository:     priceListPostgresRepository,
+		priceListPostgresCommandHandler: priceListPostgresCommandHandler,
+	}
+}
+
+func (priceList *PriceListController) GetAll(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "PriceListController.GetAll")
+	defer span.End()
+
+	priceLists, err := priceList.priceListPostgresRepository.GetAll(c.Request.Context())
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "price lists get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, priceLists)
+}
+
+func (priceList *PriceListController) GetPriceListById(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "PriceListController.GetPriceListById")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	_priceList, err := priceList.priceListPostgresRepository.FindByID(c.Request.Context(), ID)
+	if _priceList == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "price list not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, _priceList)
+}
+
+func (priceList *PriceListController) AddPriceList(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "PriceListController.AddPriceList")
+	defer span.End()
+
+	createPriceListCommand := &command_price_list.CreatePriceListCommand{}
+	err := c.BindJSON(createPriceListCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if createPriceListCommand.ID == uuid.Nil {
+		createPriceListCommand.ID = uuid.New()
+	}
+
+	priceListModel, err := priceList.priceListPostgresCommandHandler.CreatePriceListCommandHandler(ctx, createPriceListCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, priceListModel)
+}
+
+func (priceList *PriceListController) UpdatePriceList(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "PriceListController.UpdatePriceList")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid price list id")
+		return
+	}
+
+	updatePriceListCommand := &command_price_list.UpdatePriceListCommand{}
+	err = c.BindJSON(updatePriceListCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if updatePriceListCommand.ID != ID {
+		trace.FailSpan(span, "Error divergent price list id")
+		httputil.NewResponseError(c, http.StatusBadRequest, "Error divergent price list id")
+		return
+	}
+
+	priceListModel, err := priceList.priceListPostgresCommandHandler.UpdatePriceListCommandHandler(ctx, updatePriceListCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, priceListModel)
+}
+
+func (priceList *PriceListController) DeletePriceList(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "PriceListController.DeletePriceList")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid price list id")
+		return
+	}
+
+	deletePriceListCommand := &command_price_list.DeletePriceListCommand{
+		ID: ID,
+	}
+
+	err = priceList.priceListPostgresCommandHandler.DeletePriceListCommandHandler(ctx, deletePriceListCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "price list deleted")
+}
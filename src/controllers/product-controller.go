@@ -2,7 +2,10 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	command_product "product/src/application/commands/product"
 	postgres_product_command_handler "product/src/application/commands/product/postgres"
 	command_store "product/src/application/commands/store"
@@ -10,45 +13,52 @@ import (
 	repository_interface "product/src/data/repositories/interfaces"
 	redis_repository_interface "product/src/data/repositories/redis"
 	"product/src/decorators"
-	"product/src/dtos"
+	"product/src/helpers"
+	"product/src/models"
+	"sort"
 	"strconv"
+	"time"
 
 	"strings"
 
 	"github.com/JohnSalazar/microservices-go-common/httputil"
-	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
 	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+const mergePatchContentType = "application/merge-patch+json"
+
 type ProductController struct {
 	productRepositoryDecorator    decorators.ProductRepositoryDecorator
 	productMongoRepository        repository_interface.ProductRepository
-	productPostgresRepository     repository_interface.ProductRepository
+	productPostgresRepository     repository_interface.ProductSlugHistoryRepository
 	productRedisRepository        redis_repository_interface.ProductRepository
+	priceListPostgresRepository   repository_interface.PriceListRepository
+	storeStockRepository          repository_interface.StoreStockRepository
 	productPostgresCommandHandler *postgres_product_command_handler.ProductCommandHandler
 	storePostgresCommandHandler   *postgres_store_command_handler.StoreCommandHandler
-	publisher                     common_nats.Publisher
 }
 
 func NewProductController(
 	productRepositoryDecorator decorators.ProductRepositoryDecorator,
 	productMongoRepository repository_interface.ProductRepository,
-	productPostgresRepository repository_interface.ProductRepository,
+	productPostgresRepository repository_interface.ProductSlugHistoryRepository,
 	productRedisRepository redis_repository_interface.ProductRepository,
+	priceListPostgresRepository repository_interface.PriceListRepository,
+	storeStockRepository repository_interface.StoreStockRepository,
 	productPostgresCommandHandler *postgres_product_command_handler.ProductCommandHandler,
 	storePostgresCommandHandler *postgres_store_command_handler.StoreCommandHandler,
-	publisher common_nats.Publisher,
 ) *ProductController {
 	return &ProductController{
 		productRepositoryDecorator:    productRepositoryDecorator,
 		productMongoRepository:        productMongoRepository,
 		productPostgresRepository:     productPostgresRepository,
 		productRedisRepository:        productRedisRepository,
+		priceListPostgresRepository:   priceListPostgresRepository,
+		storeStockRepository:          storeStockRepository,
 		productPostgresCommandHandler: productPostgresCommandHandler,
 		storePostgresCommandHandler:   storePostgresCommandHandler,
-		publisher:                     publisher,
 	}
 }
 
@@ -56,6 +66,17 @@ func (product *ProductController) GetAll(c *gin.Context) {
 	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetAll")
 	defer span.End()
 
+	product.getAll(c, models.ProductPublished, true)
+}
+
+func (product *ProductController) GetAllAdmin(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetAllAdmin")
+	defer span.End()
+
+	product.getAll(c, strings.TrimSpace(c.Query("status")), false)
+}
+
+func (product *ProductController) getAll(c *gin.Context, status string, public bool) {
 	name := c.Param("name")
 
 	page, err := strconv.Atoi(c.Param("page"))
@@ -70,33 +91,127 @@ func (product *ProductController) GetAll(c *gin.Context) {
 		return
 	}
 
-	//products, err := product.productMongoRepository.GetAll(c.Request.Context(), page, size)
-	products, err := product.productRepositoryDecorator.GetAll(c.Request.Context(), name, page, size)
+	selector := product.priceSelector(c)
+	priceList, err := product.resolvePriceList(c.Request.Context(), selector)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attributes, err := product.attributeFilters(c)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	minRating, err := product.minRating(c)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sortBy := strings.TrimSpace(c.Query("sort"))
+	switch sortBy {
+	case "", models.ProductSortName, models.ProductSortRating, models.ProductSortRatingDesc:
+	default:
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid sort")
+		return
+	}
+
+	filter := &models.ProductFilter{
+		Name:       name,
+		Category:   c.Query("category"),
+		Brand:      c.Query("brand"),
+		SKU:        c.Query("sku"),
+		Status:     status,
+		Locale:     product.locale(c),
+		Attributes: attributes,
+		MinRating:  minRating,
+		Sort:       sortBy,
+	}
+	product.priceFilter(filter, selector, priceList)
+
+	products, err := product.productRepositoryDecorator.GetAll(c.Request.Context(), filter, page, size)
 	if err != nil {
 		httputil.NewResponseError(c, http.StatusBadRequest, "products get error")
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	priced := []*models.Product{}
+	for _, _product := range products {
+		if product.applyPrice(_product, selector, priceList) {
+			product.applyLocale(_product, filter.Locale)
+			priced = append(priced, _product)
+		}
+	}
+
+	c.Header("Content-Language", filter.Locale)
+
+	if public && notModified(c, listETag(c, filter.Locale, priced), product.modifiedAt(c, priced...)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, priced)
 }
 
 func (product *ProductController) GetProductById(c *gin.Context) {
 	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetProductById")
 	defer span.End()
 
+	product.getProductById(c, true)
+}
+
+func (product *ProductController) GetProductByIdAdmin(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetProductByIdAdmin")
+	defer span.End()
+
+	product.getProductById(c, false)
+}
+
+func (product *ProductController) getProductById(c *gin.Context, published bool) {
 	ID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
 		return
 	}
 
-	//_product, err := product.productMongoRepository.FindByID(c.Request.Context(), ID)
+	selector := product.priceSelector(c)
+	priceList, err := product.resolvePriceList(c.Request.Context(), selector)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	_product, err := product.productRepositoryDecorator.FindByID(c.Request.Context(), ID)
 	if _product == nil || err != nil {
 		httputil.NewResponseError(c, http.StatusBadRequest, "products not found")
 		return
 	}
 
+	if published && _product.Status != models.ProductPublished {
+		httputil.NewResponseError(c, http.StatusNotFound, "products not found")
+		return
+	}
+
+	if !product.applyPrice(_product, selector, priceList) {
+		httputil.NewResponseError(c, http.StatusBadRequest, "product has no price for the selected price list")
+		return
+	}
+
+	locale := product.locale(c)
+	product.applyLocale(_product, locale)
+	c.Header("Content-Language", locale)
+
+	if !published {
+		setETag(c, _product.Version)
+		c.JSON(http.StatusOK, _product)
+		return
+	}
+
+	if notModified(c, productETag(c, locale, _product), product.modifiedAt(c, _product)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, _product)
 }
 
@@ -104,19 +219,71 @@ func (product *ProductController) GetProductBySlug(c *gin.Context) {
 	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetProductBySlug")
 	defer span.End()
 
+	product.getProductBySlug(c, true)
+}
+
+func (product *ProductController) GetProductBySlugAdmin(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ProductController.GetProductBySlugAdmin")
+	defer span.End()
+
+	product.getProductBySlug(c, false)
+}
+
+func (product *ProductController) getProductBySlug(c *gin.Context, published bool) {
 	slug := c.Param("slug")
 	if strings.Trim(slug, "") == "" {
 		httputil.NewResponseError(c, http.StatusBadRequest, "invalid slug")
 		return
 	}
 
-	//_product, err := product.productMongoRepository.FindByID(c.Request.Context(), ID)
+	selector := product.priceSelector(c)
+	priceList, err := product.resolvePriceList(c.Request.Context(), selector)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	_product, err := product.productRepositoryDecorator.FindBySlug(c.Request.Context(), slug)
+	if _product == nil && err == nil {
+		current, err := product.productPostgresRepository.FindCurrentSlug(c.Request.Context(), slug)
+		if err == nil && len(current) > 0 {
+			location := url.URL{
+				Path:     strings.TrimSuffix(c.Request.URL.Path, slug) + current,
+				RawQuery: c.Request.URL.RawQuery,
+			}
+			c.Redirect(http.StatusMovedPermanently, location.String())
+			return
+		}
+	}
 	if _product == nil || err != nil {
 		httputil.NewResponseError(c, http.StatusBadRequest, "products not found")
 		return
 	}
 
+	if published && _product.Status != models.ProductPublished {
+		httputil.NewResponseError(c, http.StatusNotFound, "products not found")
+		return
+	}
+
+	if !product.applyPrice(_product, selector, priceList) {
+		httputil.NewResponseError(c, http.StatusBadRequest, "product has no price for the selected price list")
+		return
+	}
+
+	locale := product.locale(c)
+	product.applyLocale(_product, locale)
+	c.Header("Content-Language", locale)
+
+	if !published {
+		setETag(c, _product.Version)
+		c.JSON(http.StatusOK, _product)
+		return
+	}
+
+	if notModified(c, productETag(c, locale, _product), product.modifiedAt(c, _product)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, _product)
 }
 
@@ -138,7 +305,7 @@ func (product *ProductController) AddProduct(c *gin.Context) {
 
 	productModel, err := product.productPostgresCommandHandler.CreateProductCommandHandler(ctx, createProductPostgresCommand)
 	if err != nil {
-		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
 		return
 	}
 
@@ -169,96 +336,428 @@ func (product *ProductController) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	version, ok := product.matchVersion(c, ID)
+	if !ok {
+		trace.FailSpan(span, "Error precondition failed")
+		return
+	}
+	if version > 0 {
+		updateProductPostgresCommand.Version = version
+	}
+
 	productModel, err := product.productPostgresCommandHandler.UpdateProductCommandHandler(ctx, updateProductPostgresCommand)
 	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, productModel.Version)
+	c.JSON(http.StatusOK, productModel)
+}
+
+func (product *ProductController) PatchProduct(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.PatchProduct")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != mergePatchContentType && contentType != gin.MIMEJSON {
+		trace.FailSpan(span, "Error unsupported content type")
+		httputil.NewResponseError(c, http.StatusUnsupportedMediaType, fmt.Sprintf("content type must be %s", mergePatchContentType))
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		trace.FailSpan(span, "Error read body")
 		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	version, ok := product.matchVersion(c, ID)
+	if !ok {
+		trace.FailSpan(span, "Error precondition failed")
+		return
+	}
+
+	patchProductCommand := &command_product.PatchProductCommand{
+		ID:      ID,
+		Patch:   patch,
+		Version: version,
+	}
+
+	productModel, err := product.productPostgresCommandHandler.PatchProductCommandHandler(ctx, patchProductCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	setETag(c, productModel.Version)
 	c.JSON(http.StatusOK, productModel)
 }
 
-func (product *ProductController) Book(c *gin.Context) {
-	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.Book")
+func (product *ProductController) matchVersion(c *gin.Context, ID uuid.UUID) (uint, bool) {
+	if len(c.GetHeader("If-Match")) == 0 {
+		return 0, true
+	}
+
+	current, err := product.productPostgresRepository.FindByID(c.Request.Context(), ID)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return 0, false
+	}
+	if current == nil {
+		httputil.NewResponseError(c, http.StatusPreconditionFailed, "product not found")
+		return 0, false
+	}
+
+	if !ifMatch(c, current.Version) {
+		httputil.NewResponseError(c, http.StatusPreconditionFailed, "product has been changed by another request")
+		return 0, false
+	}
+
+	return current.Version, true
+}
+
+func (product *ProductController) DeleteProduct(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.DeleteProduct")
 	defer span.End()
 
-	bookStoreCommand := &command_store.BookStoreCommand{}
-	err := c.BindJSON(bookStoreCommand)
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	deleteProductCommand := &command_product.DeleteProductCommand{
+		ID: ID,
+	}
+
+	err = product.productPostgresCommandHandler.DeleteProductCommandHandler(ctx, deleteProductCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "product deleted")
+}
+
+func (product *ProductController) RestoreProduct(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.RestoreProduct")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	restoreProductCommand := &command_product.RestoreProductCommand{
+		ID: ID,
+	}
+
+	productModel, err := product.productPostgresCommandHandler.RestoreProductCommandHandler(ctx, restoreProductCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, productModel)
+}
+
+func (product *ProductController) GetRelatedById(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.GetRelatedById")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	_product, err := product.productRepositoryDecorator.FindByID(ctx, ID)
+	product.getRelated(c, _product, err)
+}
+
+func (product *ProductController) GetRelatedBySlug(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.GetRelatedBySlug")
+	defer span.End()
+
+	slug := c.Param("slug")
+	if strings.TrimSpace(slug) == "" {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid slug")
+		return
+	}
+
+	_product, err := product.productRepositoryDecorator.FindBySlug(ctx, slug)
+	product.getRelated(c, _product, err)
+}
+
+func (product *ProductController) getRelated(c *gin.Context, _product *models.Product, err error) {
+	if _product == nil || err != nil || _product.Status != models.ProductPublished {
+		httputil.NewResponseError(c, http.StatusBadRequest, "products not found")
+		return
+	}
+
+	selector := product.priceSelector(c)
+	priceList, err := product.resolvePriceList(c.Request.Context(), selector)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	products, err := product.productRepositoryDecorator.GetRelated(c.Request.Context(), _product, c.Query("type"), models.ProductPublished)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "products get error")
+		return
+	}
+
+	locale := product.locale(c)
+	priced := []*models.Product{}
+	for _, related := range products {
+		if product.applyPrice(related, selector, priceList) {
+			product.applyLocale(related, locale)
+			priced = append(priced, related)
+		}
+	}
+
+	c.Header("Content-Language", locale)
+
+	c.JSON(http.StatusOK, priced)
+}
+
+func (product *ProductController) SetProductRelations(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.SetProductRelations")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	setProductRelationsCommand := &command_product.SetProductRelationsCommand{}
+	err = c.BindJSON(setProductRelationsCommand)
 	if err != nil {
 		trace.FailSpan(span, "Error json parse")
 		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err = product.storePostgresCommandHandler.BookStoreCommandHandler(ctx, bookStoreCommand)
+	setProductRelationsCommand.ID = ID
+
+	productModel, err := product.productPostgresCommandHandler.SetProductRelationsCommandHandler(ctx, setProductRelationsCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, productModel)
+}
+
+func (product *ProductController) ChangeProductStatus(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.ChangeProductStatus")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	changeProductStatusCommand := &command_product.ChangeProductStatusCommand{}
+	err = c.BindJSON(changeProductStatusCommand)
 	if err != nil {
+		trace.FailSpan(span, "Error json parse")
 		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// dataPostgres, err := json.Marshal(bookStorePostgresCommand)
-	// if err != nil {
-	// 	trace.FailSpan(span, "error json parse")
-	// 	httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
-	// 	return
-	// }
+	changeProductStatusCommand.ID = ID
 
-	// err = product.publisher.Publish(string(common_nats.StoreBook), dataPostgres)
-	// if err != nil {
-	// 	httputil.NewResponseError(c, http.StatusBadRequest, fmt.Sprintf("%s error: %s", common_nats.StoreBook, err.Error()))
-	// 	return
-	// }
+	productModel, err := product.productPostgresCommandHandler.ChangeProductStatusCommandHandler(ctx, changeProductStatusCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, productModel)
+}
+
+func (product *ProductController) Restock(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.Restock")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	createStoreCommand := &command_store.CreateStoreCommand{}
+	err = c.BindJSON(createStoreCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	createStoreCommand.ProductID = ID
+
+	if !product.checkStockTarget(c, ID, createStoreCommand.VariantID) {
+		trace.FailSpan(span, "Error invalid stock target")
+		return
+	}
 
-	bookStoreDTO := &dtos.BookStore{
-		Products: bookStoreCommand.Products,
+	err = product.storePostgresCommandHandler.CreateStoreCommandHandler(ctx, createStoreCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
 	}
 
-	c.JSON(http.StatusOK, bookStoreDTO)
+	product.stockLevel(c, ID, createStoreCommand.VariantID)
 }
 
-func (product *ProductController) Payment(c *gin.Context) {
-	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.Payment")
+func (product *ProductController) WriteOff(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.WriteOff")
 	defer span.End()
 
-	paymentStoreCommand := &command_store.PaymentStoreCommand{}
-	err := c.BindJSON(paymentStoreCommand)
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	writeOffStoreCommand := &command_store.WriteOffStoreCommand{}
+	err = c.BindJSON(writeOffStoreCommand)
 	if err != nil {
 		trace.FailSpan(span, "Error json parse")
 		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	writeOffStoreCommand.ProductID = ID
+
+	if !product.checkStockTarget(c, ID, writeOffStoreCommand.VariantID) {
+		trace.FailSpan(span, "Error invalid stock target")
+		return
+	}
+
+	err = product.storePostgresCommandHandler.WriteOffStoreCommandHandler(ctx, writeOffStoreCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	product.stockLevel(c, ID, writeOffStoreCommand.VariantID)
+}
+
+func (product *ProductController) SetStock(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductController.SetStock")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
 
-	stores, err := product.storePostgresCommandHandler.PaymentStoreCommandHandler(ctx, paymentStoreCommand)
+	setStockCommand := &command_store.SetStockCommand{}
+	err = c.BindJSON(setStockCommand)
 	if err != nil {
+		trace.FailSpan(span, "Error json parse")
 		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	setStockCommand.ProductID = ID
+
+	if !product.checkStockTarget(c, ID, setStockCommand.VariantID) {
+		trace.FailSpan(span, "Error invalid stock target")
+		return
+	}
 
-	// dataPostgres, err := json.Marshal(paymentStorePostgresCommand)
-	// if err != nil {
-	// 	trace.FailSpan(span, "error json parse")
-	// 	httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
-	// 	return
-	// }
+	err = product.storePostgresCommandHandler.SetStockCommandHandler(ctx, setStockCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
 
-	// err = product.publisher.Publish(string(common_nats.StorePaymentPostgres), dataPostgres)
-	// if err != nil {
-	// 	httputil.NewResponseError(c, http.StatusBadRequest, fmt.Sprintf("%s error: %s", common_nats.StorePaymentPostgres, err.Error()))
-	// 	return
-	// }
+	product.stockLevel(c, ID, setStockCommand.VariantID)
+}
 
-	paymentsStoreDTO := []*dtos.PaymentStore{}
-	for _, store := range stores {
-		paymentStoreDTO := &dtos.PaymentStore{
-			ID:   store.ID,
-			Sold: store.Sold,
+func (product *ProductController) checkStockTarget(c *gin.Context, ID uuid.UUID, variantID uuid.NullUUID) bool {
+	productModel, err := product.productPostgresRepository.FindByID(c.Request.Context(), ID)
+	if productModel == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "product not found")
+		return false
+	}
+
+	if productModel.Type == models.ProductBundle {
+		httputil.NewResponseError(c, http.StatusBadRequest, "bundle stock comes from its components")
+		return false
+	}
+
+	if len(productModel.Variants) == 0 {
+		if variantID.Valid {
+			httputil.NewResponseError(c, http.StatusBadRequest, "product has no variants")
+			return false
 		}
+		return true
+	}
 
-		paymentsStoreDTO = append(paymentsStoreDTO, paymentStoreDTO)
+	if !variantID.Valid {
+		httputil.NewResponseError(c, http.StatusBadRequest, "variant is required for products with variants")
+		return false
+	}
 
+	for _, variant := range productModel.Variants {
+		if variant.ID == variantID.UUID {
+			return true
+		}
 	}
 
-	c.JSON(http.StatusOK, paymentsStoreDTO)
+	httputil.NewResponseError(c, http.StatusBadRequest, "variant not found")
+	return false
+}
+
+// modifiedAt is the Last-Modified time of the products, zero when their stock history cannot be read
+// so that only the ETag validates the response.
+func (product *ProductController) modifiedAt(c *gin.Context, products ...*models.Product) time.Time {
+	if len(products) == 0 {
+		return time.Time{}
+	}
+
+	IDs := []uuid.UUID{}
+	for _, _product := range products {
+		IDs = append(IDs, _product.ID)
+		for _, component := range _product.Components {
+			IDs = append(IDs, component.ProductID)
+		}
+	}
+
+	stockChangedAt, err := product.storeStockRepository.LastStockChange(c.Request.Context(), IDs)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return lastModified(stockChangedAt, products...)
+}
+
+func (product *ProductController) stockLevel(c *gin.Context, ID uuid.UUID, variantID uuid.NullUUID) {
+	quantity, err := product.storeStockRepository.CountAvailable(c.Request.Context(), ID, variantID)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.StockLevel{
+		ProductID: ID,
+		VariantID: variantID,
+		Quantity:  quantity,
+	})
 }
 
 func (product *ProductController) Refresh(c *gin.Context) {
@@ -267,7 +766,7 @@ func (product *ProductController) Refresh(c *gin.Context) {
 		_, span := trace.NewSpan(c.Request.Context(), "ProductController.Refresh")
 		defer span.End()
 
-		products, err := product.productMongoRepository.GetAll(ctx, "", 0, 0)
+		products, err := product.productMongoRepository.GetAll(ctx, &models.ProductFilter{}, 0, 0)
 		if err != nil {
 			httputil.NewResponseError(c, http.StatusBadRequest, "products get error")
 			return
@@ -282,3 +781,141 @@ func (product *ProductController) Refresh(c *gin.Context) {
 
 	c.JSON(http.StatusOK, "refresh requested")
 }
+
+func (product *ProductController) locale(c *gin.Context) string {
+	locale := strings.TrimSpace(c.Query("locale"))
+	if len(locale) == 0 {
+		locale = c.GetHeader("Accept-Language")
+	}
+
+	return helpers.MatchLocale(locale)
+}
+
+func (product *ProductController) attributeFilters(c *gin.Context) ([]*models.AttributeFilter, error) {
+	filters := []*models.AttributeFilter{}
+	for key, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(key, "attr.") || len(values) == 0 {
+			continue
+		}
+
+		filter := &models.AttributeFilter{
+			Code:  strings.TrimPrefix(key, "attr."),
+			Value: strings.TrimSpace(values[0]),
+		}
+
+		if bounds := strings.SplitN(filter.Value, "..", 2); len(bounds) == 2 {
+			for i, bound := range bounds {
+				if len(bound) == 0 {
+					continue
+				}
+
+				value, err := strconv.ParseFloat(bound, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid range for attribute %s", filter.Code)
+				}
+
+				if i == 0 {
+					filter.Min = &value
+				} else {
+					filter.Max = &value
+				}
+			}
+			filter.Value = ""
+		}
+
+		if len(filter.Code) == 0 || (len(filter.Value) == 0 && !filter.Range()) {
+			return nil, fmt.Errorf("invalid filter for attribute %s", filter.Code)
+		}
+
+		filters = append(filters, filter)
+	}
+
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Code < filters[j].Code })
+
+	return filters, nil
+}
+
+func (product *ProductController) minRating(c *gin.Context) (float64, error) {
+	rating := strings.TrimSpace(c.Query("rating"))
+	if len(rating) == 0 {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(rating, 64)
+	if err != nil || value < 0 || value > 5 {
+		return 0, errors.New("invalid rating")
+	}
+
+	return value, nil
+}
+
+func (product *ProductController) applyLocale(_product *models.Product, locale string) {
+	translation := models.Translation(_product.Translations, locale)
+	if translation == nil {
+		return
+	}
+
+	_product.Name = translation.Name
+	_product.Slug = translation.Slug
+	_product.Description = translation.Description
+}
+
+func (product *ProductController) priceSelector(c *gin.Context) *models.PriceSelector {
+	return &models.PriceSelector{
+		PriceList: strings.TrimSpace(c.Query("priceList")),
+		Currency:  strings.ToUpper(strings.TrimSpace(c.Query("currency"))),
+		Channel:   strings.TrimSpace(c.Query("channel")),
+	}
+}
+
+func (product *ProductController) resolvePriceList(ctx context.Context, selector *models.PriceSelector) (*models.PriceList, error) {
+	if len(selector.PriceList) > 0 {
+		priceList, err := product.priceListPostgresRepository.FindByCode(ctx, selector.PriceList)
+		if err != nil {
+			return nil, err
+		}
+		if priceList == nil {
+			return nil, errors.New("price list not found")
+		}
+
+		return priceList, nil
+	}
+
+	if len(selector.Currency) > 0 {
+		return product.priceListPostgresRepository.FindByCurrency(ctx, selector.Currency, selector.Channel)
+	}
+
+	return nil, nil
+}
+
+func (product *ProductController) applyPrice(_product *models.Product, selector *models.PriceSelector, priceList *models.PriceList) bool {
+	if len(selector.PriceList) == 0 && len(selector.Currency) == 0 {
+		return true
+	}
+
+	if priceList != nil {
+		price := models.ListPrice(_product.Prices, priceList.ID)
+		if price != nil {
+			_product.Price = *price
+			_product.SalePrice = models.PriceListSalePrice(_product.Promotions, priceList.ID, time.Now().UTC())
+			for _, variant := range _product.Variants {
+				variant.Price = models.ListPrice(variant.Prices, priceList.ID)
+			}
+
+			return true
+		}
+	}
+
+	return len(selector.PriceList) == 0 && _product.Price.Currency == selector.Currency
+}
+
+// priceFilter narrows the product query to what applyPrice can price, so pages are not cut short afterwards.
+func (product *ProductController) priceFilter(filter *models.ProductFilter, selector *models.PriceSelector, priceList *models.PriceList) {
+	if priceList != nil {
+		filter.PriceListID = uuid.NullUUID{UUID: priceList.ID, Valid: true}
+	}
+
+	if len(selector.PriceList) == 0 {
+		filter.Currency = selector.Currency
+	}
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"product/src/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func testMoney(amount string, currency string) models.Money {
+	return models.NewMoney(decimal.RequireFromString(amount), currency)
+}
+
+func TestApplyPrice(t *testing.T) {
+	usd := &models.PriceList{ID: uuid.New(), Code: "usd", Currency: "USD"}
+	usdMobile := &models.PriceList{ID: uuid.New(), Code: "usd-mobile", Currency: "USD", Channel: "mobile"}
+	now := time.Now().UTC()
+
+	newProduct := func() *models.Product {
+		promotions := []*models.ProductPromotion{
+			{ID: uuid.New(), Price: testMoney("80.00", "BRL"), StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+			{ID: uuid.New(), PriceListID: uuid.NullUUID{UUID: usd.ID, Valid: true}, Price: testMoney("15.00", "USD"), StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		}
+		basePrice := testMoney("110.00", "BRL")
+
+		return &models.Product{
+			Price: testMoney("100.00", "BRL"),
+			Prices: []*models.ProductPrice{
+				{PriceListID: usd.ID, Price: testMoney("20.00", "USD")},
+				{PriceListID: usdMobile.ID, Price: testMoney("19.00", "USD")},
+			},
+			Promotions: promotions,
+			SalePrice:  models.SalePrice(promotions, now),
+			Variants: []*models.ProductVariant{
+				{SKU: "listed", Price: &basePrice, Prices: []*models.ProductPrice{{PriceListID: usd.ID, Price: testMoney("22.00", "USD")}}},
+				{SKU: "unlisted", Price: &basePrice},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		selector  *models.PriceSelector
+		priceList *models.PriceList
+		ok        bool
+		price     string
+		salePrice string
+		variants  []string
+	}{
+		{"no selector", &models.PriceSelector{}, nil, true, "100", "80", []string{"110", "110"}},
+		{"price list", &models.PriceSelector{PriceList: "usd"}, usd, true, "20", "15", []string{"22", ""}},
+		{"other list keeps its own promotions", &models.PriceSelector{Currency: "USD", Channel: "mobile"}, usdMobile, true, "19", "", []string{"", ""}},
+		{"base currency without list", &models.PriceSelector{Currency: "BRL"}, nil, true, "100", "80", []string{"110", "110"}},
+		{"currency without price", &models.PriceSelector{Currency: "EUR"}, nil, false, "", "", nil},
+	}
+
+	controller := &ProductController{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_product := newProduct()
+			if got := controller.applyPrice(_product, test.selector, test.priceList); got != test.ok {
+				t.Fatalf("applyPrice() = %v, want %v", got, test.ok)
+			}
+			if !test.ok {
+				return
+			}
+
+			if !_product.Price.Amount.Equal(decimal.RequireFromString(test.price)) {
+				t.Errorf("price = %s, want %s", _product.Price.Amount, test.price)
+			}
+
+			if len(test.salePrice) == 0 && _product.SalePrice != nil {
+				t.Errorf("sale price = %s, want none", _product.SalePrice.Amount)
+			}
+			if len(test.salePrice) > 0 && (_product.SalePrice == nil || !_product.SalePrice.Amount.Equal(decimal.RequireFromString(test.salePrice))) {
+				t.Errorf("sale price = %v, want %s", _product.SalePrice, test.salePrice)
+			}
+
+			for i, want := range test.variants {
+				variant := _product.Variants[i]
+				if len(want) == 0 && variant.Price != nil {
+					t.Errorf("%s price = %s, want none", variant.SKU, variant.Price.Amount)
+				}
+				if len(want) > 0 && (variant.Price == nil || !variant.Price.Amount.Equal(decimal.RequireFromString(want))) {
+					t.Errorf("%s price = %v, want %s", variant.SKU, variant.Price, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPriceFilter(t *testing.T) {
+	priceList := &models.PriceList{ID: uuid.New(), Currency: "USD"}
+
+	tests := []struct {
+		name        string
+		selector    *models.PriceSelector
+		priceList   *models.PriceList
+		priceListID uuid.NullUUID
+		currency    string
+	}{
+		{"no selector", &models.PriceSelector{}, nil, uuid.NullUUID{}, ""},
+		{"price list", &models.PriceSelector{PriceList: "usd"}, priceList, uuid.NullUUID{UUID: priceList.ID, Valid: true}, ""},
+		{"currency with list", &models.PriceSelector{Currency: "USD"}, priceList, uuid.NullUUID{UUID: priceList.ID, Valid: true}, "USD"},
+		{"currency without list", &models.PriceSelector{Currency: "USD"}, nil, uuid.NullUUID{}, "USD"},
+	}
+
+	controller := &ProductController{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &models.ProductFilter{}
+			controller.priceFilter(filter, test.selector, test.priceList)
+
+			if filter.PriceListID != test.priceListID {
+				t.Errorf("price list id = %v, want %v", filter.PriceListID, test.priceListID)
+			}
+			if filter.Currency != test.currency {
+				t.Errorf("currency = %s, want %s", filter.Currency, test.currency)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	command_product "product/src/application/commands/product"
+	postgres_product_command_handler "product/src/application/commands/product/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"product/src/export"
+	"product/src/models"
+	"strings"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProductImportController struct {
+	productImportRepository     repository_interface.ProductImportRepository
+	productExportRepository     repository_interface.ProductExportRepository
+	productImportCommandHandler *postgres_product_command_handler.ProductImportCommandHandler
+}
+
+func NewProductImportController(
+	productImportRepository repository_interface.ProductImportRepository,
+	productExportRepository repository_interface.ProductExportRepository,
+	productImportCommandHandler *postgres_product_command_handler.ProductImportCommandHandler,
+) *ProductImportController {
+	return &ProductImportController{
+		productImportRepository:     productImportRepository,
+		productExportRepository:     productExportRepository,
+		productImportCommandHandler: productImportCommandHandler,
+	}
+}
+
+func (productImport *ProductImportController) ImportProducts(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductImportController.ImportProducts")
+	defer span.End()
+
+	data, format, err := productImport.importFile(c)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	importProductsCommand := &command_product.ImportProductsCommand{
+		ID:     uuid.New(),
+		Format: format,
+		Data:   data,
+	}
+
+	if userID := c.GetString("user"); primitive.IsValidObjectID(userID) {
+		importProductsCommand.CreatedBy = userID
+	}
+
+	productImportModel, err := productImport.productImportCommandHandler.ImportProductsCommandHandler(ctx, importProductsCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusAccepted, productImportModel)
+}
+
+func (productImport *ProductImportController) ExportProducts(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ProductImportController.ExportProducts")
+	defer span.End()
+
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", models.FormatCSV)))
+	deleted := c.Query("deleted") == "true"
+
+	contentType, ok := export.ContentTypes[format]
+	if !ok {
+		httputil.NewResponseError(c, http.StatusBadRequest, fmt.Sprintf("export format %s is not supported", format))
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=products.%s", format))
+	c.Status(http.StatusOK)
+
+	writer, err := export.NewProductWriter(c.Writer, format)
+	if err == nil {
+		err = productImport.productExportRepository.Export(ctx, deleted, func(_product *models.Product) error {
+			return writer.Write(_product)
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		trace.FailSpan(span, fmt.Sprintf("export error: %s", err.Error()))
+		c.Error(err)
+	}
+}
+
+func (productImport *ProductImportController) GetImport(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ProductImportController.GetImport")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid import id")
+		return
+	}
+
+	productImportModel, err := productImport.productImportRepository.FindByID(c.Request.Context(), ID)
+	if productImportModel == nil || err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "import not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, productImportModel)
+}
+
+func (productImport *ProductImportController) importFile(c *gin.Context) ([]byte, string, error) {
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+
+	var reader io.Reader = c.Request.Body
+	fileHeader, err := c.FormFile("file")
+	if err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+
+		reader = file
+		if len(format) == 0 {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+	}
+
+	if len(format) == 0 {
+		switch c.ContentType() {
+		case "text/csv":
+			format = models.FormatCSV
+		case "application/json":
+			format = models.FormatJSON
+		case "application/x-ndjson", "application/ndjson":
+			format = models.FormatNDJSON
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, models.ImportMaxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, format, nil
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	command_store "product/src/application/commands/store"
+	postgres_store_command_handler "product/src/application/commands/store/postgres"
+	"product/src/dtos"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReservationController struct {
+	storePostgresCommandHandler *postgres_store_command_handler.StoreCommandHandler
+}
+
+func NewReservationController(
+	storePostgresCommandHandler *postgres_store_command_handler.StoreCommandHandler,
+) *ReservationController {
+	return &ReservationController{
+		storePostgresCommandHandler: storePostgresCommandHandler,
+	}
+}
+
+func (reservation *ReservationController) Book(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReservationController.Book")
+	defer span.End()
+
+	bookStoreCommand := &command_store.BookStoreCommand{}
+	err := c.BindJSON(bookStoreCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// the reservation owner is always the caller; customerId is only trusted on the NATS command
+	bookStoreCommand.CustomerID = primitive.NilObjectID
+	customerID, err := primitive.ObjectIDFromHex(c.GetString("user"))
+	if err == nil {
+		bookStoreCommand.CustomerID = customerID
+	}
+
+	err = reservation.storePostgresCommandHandler.BookStoreCommandHandler(ctx, bookStoreCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	bookStoreDTO := &dtos.BookStore{
+		Products: bookStoreCommand.Products,
+	}
+
+	c.JSON(http.StatusOK, bookStoreDTO)
+}
+
+func (reservation *ReservationController) ExtendReservation(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReservationController.ExtendReservation")
+	defer span.End()
+
+	orderID, err := primitive.ObjectIDFromHex(c.Param("orderId"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	extendReservationCommand := &command_store.ExtendReservationCommand{}
+	err = c.ShouldBindJSON(extendReservationCommand)
+	if err != nil && err != io.EOF {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	extendReservationCommand.OrderID = orderID
+	extendReservationCommand.CustomerID = primitive.NilObjectID
+	customerID, err := primitive.ObjectIDFromHex(c.GetString("user"))
+	if err == nil {
+		extendReservationCommand.CustomerID = customerID
+	}
+	extendReservationCommand.Admin = hasClaim(c, "admin", "update")
+
+	reservationModel, err := reservation.storePostgresCommandHandler.ExtendReservationCommandHandler(ctx, extendReservationCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, reservationModel)
+}
+
+func (reservation *ReservationController) Payment(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReservationController.Payment")
+	defer span.End()
+
+	paymentStoreCommand := &command_store.PaymentStoreCommand{}
+	err := c.BindJSON(paymentStoreCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stores, err := reservation.storePostgresCommandHandler.PaymentStoreCommandHandler(ctx, paymentStoreCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	paymentsStoreDTO := []*dtos.PaymentStore{}
+	for _, store := range stores {
+		paymentStoreDTO := &dtos.PaymentStore{
+			ID:   store.ID,
+			Sold: store.Sold,
+		}
+
+		paymentsStoreDTO = append(paymentsStoreDTO, paymentStoreDTO)
+	}
+
+	c.JSON(http.StatusOK, paymentsStoreDTO)
+}
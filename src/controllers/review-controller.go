@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	command_review "product/src/application/commands/review"
+	postgres_review_command_handler "product/src/application/commands/review/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"product/src/models"
+	"strconv"
+	"strings"
+
+	"github.com/JohnSalazar/microservices-go-common/httputil"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewController struct {
+	reviewPostgresRepository     repository_interface.ReviewRepository
+	reviewPostgresCommandHandler *postgres_review_command_handler.ReviewCommandHandler
+}
+
+func NewReviewController(
+	reviewPostgresRepository repository_interface.ReviewRepository,
+	reviewPostgresCommandHandler *postgres_review_command_handler.ReviewCommandHandler,
+) *ReviewController {
+	return &ReviewController{
+		reviewPostgresRepository:     reviewPostgresRepository,
+		reviewPostgresCommandHandler: reviewPostgresCommandHandler,
+	}
+}
+
+func (review *ReviewController) GetProductReviews(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ReviewController.GetProductReviews")
+	defer span.End()
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	filter := &models.ReviewFilter{
+		ProductID: uuid.NullUUID{UUID: productID, Valid: true},
+		Status:    models.ReviewApproved,
+	}
+
+	review.getAll(c, filter)
+}
+
+func (review *ReviewController) GetAllAdmin(c *gin.Context) {
+	_, span := trace.NewSpan(c.Request.Context(), "ReviewController.GetAllAdmin")
+	defer span.End()
+
+	filter := &models.ReviewFilter{
+		Status: strings.TrimSpace(c.Query("status")),
+	}
+
+	product := strings.TrimSpace(c.Query("product"))
+	if len(product) > 0 {
+		productID, err := uuid.Parse(product)
+		if err != nil {
+			httputil.NewResponseError(c, http.StatusBadRequest, "invalid product id")
+			return
+		}
+		filter.ProductID = uuid.NullUUID{UUID: productID, Valid: true}
+	}
+
+	review.getAll(c, filter)
+}
+
+func (review *ReviewController) getAll(c *gin.Context, filter *models.ReviewFilter) {
+	page, err := strconv.Atoi(c.Param("page"))
+	if err != nil || page < 1 {
+		httputil.NewResponseError(c, http.StatusBadRequest, "page is required")
+		return
+	}
+
+	size, err := strconv.Atoi(c.Param("size"))
+	if err != nil || size < 1 {
+		httputil.NewResponseError(c, http.StatusBadRequest, "size is required")
+		return
+	}
+
+	reviews, err := review.reviewPostgresRepository.GetAll(c.Request.Context(), filter, page, size)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "reviews get error")
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+func (review *ReviewController) AddReview(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReviewController.AddReview")
+	defer span.End()
+
+	userID := c.GetString("user")
+	if !primitive.IsValidObjectID(userID) {
+		httputil.NewResponseError(c, http.StatusUnauthorized, "invalid user id")
+		return
+	}
+
+	createReviewCommand := &command_review.CreateReviewCommand{}
+	err := c.BindJSON(createReviewCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createReviewCommand.UserID = userID
+	if createReviewCommand.ID == uuid.Nil {
+		createReviewCommand.ID = uuid.New()
+	}
+
+	reviewModel, err := review.reviewPostgresCommandHandler.CreateReviewCommandHandler(ctx, createReviewCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, reviewModel)
+}
+
+func (review *ReviewController) ModerateReview(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReviewController.ModerateReview")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	moderateReviewCommand := &command_review.ModerateReviewCommand{}
+	err = c.BindJSON(moderateReviewCommand)
+	if err != nil {
+		trace.FailSpan(span, "Error json parse")
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	moderateReviewCommand.ID = ID
+
+	reviewModel, err := review.reviewPostgresCommandHandler.ModerateReviewCommandHandler(ctx, moderateReviewCommand)
+	if err != nil {
+		httputil.NewResponseError(c, errorStatus(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, reviewModel)
+}
+
+func (review *ReviewController) DeleteReview(c *gin.Context) {
+	ctx, span := trace.NewSpan(c.Request.Context(), "ReviewController.DeleteReview")
+	defer span.End()
+
+	ID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	deleteReviewCommand := &command_review.DeleteReviewCommand{
+		ID: ID,
+	}
+
+	err = review.reviewPostgresCommandHandler.DeleteReviewCommandHandler(ctx, deleteReviewCommand)
+	if err != nil {
+		httputil.NewResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, "review deleted")
+}
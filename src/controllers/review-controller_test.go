@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	postgres_review_command_handler "product/src/application/commands/review/postgres"
+	repository_interface "product/src/data/repositories/interfaces"
+	"product/src/models"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reviewRepositoryStub struct {
+	repository_interface.ReviewRepository
+	created *models.Review
+}
+
+func (r *reviewRepositoryStub) FindByUser(ctx context.Context, productID uuid.UUID, userID string) (*models.Review, error) {
+	return nil, nil
+}
+
+func (r *reviewRepositoryStub) Create(ctx context.Context, review *models.Review) (*models.Review, error) {
+	r.created = review
+	return review, nil
+}
+
+type reviewProductRepositoryStub struct {
+	repository_interface.ProductRepository
+}
+
+func (r *reviewProductRepositoryStub) FindByID(ctx context.Context, ID uuid.UUID) (*models.Product, error) {
+	return &models.Product{ID: ID, Status: models.ProductPublished}, nil
+}
+
+type eventSourcingRepositoryStub struct {
+	repository_interface.EventSourcingRepository
+}
+
+func (r *eventSourcingRepositoryStub) Create(ctx context.Context, eventStore *models.EventSourcing) error {
+	return nil
+}
+
+func TestAddReview(t *testing.T) {
+	objectID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name   string
+		user   string
+		status int
+	}{
+		{"object id subject", objectID, http.StatusCreated},
+		{"uuid subject", uuid.New().String(), http.StatusUnauthorized},
+		{"no subject", "", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reviewRepository := &reviewRepositoryStub{}
+			controller := NewReviewController(reviewRepository, postgres_review_command_handler.NewReviewCommandHandler(
+				reviewRepository,
+				&reviewProductRepositoryStub{},
+				&eventSourcingRepositoryStub{},
+				nil,
+			))
+
+			c, recorder := testContext(nil)
+			body := `{"productid":"` + uuid.New().String() + `","rating":5,"title":"Great"}`
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user", test.user)
+
+			controller.AddReview(c)
+
+			if recorder.Code != test.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, test.status, recorder.Body.String())
+			}
+			if test.status != http.StatusCreated {
+				if reviewRepository.created != nil {
+					t.Errorf("review created for subject %q", test.user)
+				}
+				return
+			}
+
+			if reviewRepository.created == nil || reviewRepository.created.UserID != test.user {
+				t.Errorf("review user = %v, want %s", reviewRepository.created, test.user)
+			}
+		})
+	}
+}
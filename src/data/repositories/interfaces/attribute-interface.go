@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type AttributeRepository interface {
+	GetAll(ctx context.Context) ([]*models.Attribute, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.Attribute, error)
+	FindByCode(ctx context.Context, code string) (*models.Attribute, error)
+	InUse(ctx context.Context, ID uuid.UUID) (bool, error)
+	Create(ctx context.Context, attribute *models.Attribute) (*models.Attribute, error)
+	Update(ctx context.Context, attribute *models.Attribute) (*models.Attribute, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type BrandRepository interface {
+	GetAll(ctx context.Context) ([]*models.Brand, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.Brand, error)
+	FindBySlug(ctx context.Context, slug string) (*models.Brand, error)
+	InUse(ctx context.Context, ID uuid.UUID) (bool, error)
+	Create(ctx context.Context, brand *models.Brand) (*models.Brand, error)
+	Update(ctx context.Context, brand *models.Brand) (*models.Brand, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
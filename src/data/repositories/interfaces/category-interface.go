@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type CategoryRepository interface {
+	GetAll(ctx context.Context) ([]*models.Category, error)
+	GetChildren(ctx context.Context, parentID uuid.UUID) ([]*models.Category, error)
+	GetDescendantIDs(ctx context.Context, ID uuid.UUID) ([]uuid.UUID, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.Category, error)
+	FindBySlug(ctx context.Context, slug string) (*models.Category, error)
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	Update(ctx context.Context, category *models.Category) (*models.Category, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
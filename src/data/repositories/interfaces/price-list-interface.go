@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type PriceListRepository interface {
+	GetAll(ctx context.Context) ([]*models.PriceList, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.PriceList, error)
+	FindByCode(ctx context.Context, code string) (*models.PriceList, error)
+	FindByCurrency(ctx context.Context, currency string, channel string) (*models.PriceList, error)
+	InUse(ctx context.Context, ID uuid.UUID) (bool, error)
+	Create(ctx context.Context, priceList *models.PriceList) (*models.PriceList, error)
+	Update(ctx context.Context, priceList *models.PriceList) (*models.PriceList, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
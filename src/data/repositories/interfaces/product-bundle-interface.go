@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type ProductBundleRepository interface {
+	FindComponents(ctx context.Context, bundleID uuid.UUID) ([]*models.ProductComponent, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+)
+
+type ProductExportRepository interface {
+	Export(ctx context.Context, deleted bool, fn func(product *models.Product) error) error
+}
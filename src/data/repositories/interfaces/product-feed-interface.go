@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+)
+
+type ProductFeedRepository interface {
+	GetFeed(ctx context.Context) ([]*models.Product, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductImportRepository interface {
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.ProductImport, error)
+	Create(ctx context.Context, productImport *models.ProductImport) (*models.ProductImport, error)
+	Update(ctx context.Context, productImport *models.ProductImport) (*models.ProductImport, error)
+	FailRunning(ctx context.Context, finishedAt time.Time) (int64, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type ProductPatchRepository interface {
+	Patch(ctx context.Context, ID uuid.UUID, patch map[string]interface{}) (*models.Product, error)
+}
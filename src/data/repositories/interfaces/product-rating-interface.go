@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+)
+
+type ProductRatingRepository interface {
+	SetRating(ctx context.Context, rating *models.ProductRating) (*models.Product, error)
+}
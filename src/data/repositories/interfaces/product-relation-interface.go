@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+)
+
+type ProductRelationRepository interface {
+	UpdateRelations(ctx context.Context, product *models.Product) (*models.Product, error)
+}
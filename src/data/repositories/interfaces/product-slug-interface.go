@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+)
+
+type ProductSlugRepository interface {
+	FindCurrentSlug(ctx context.Context, slug string) (string, error)
+}
+
+type ProductSlugHistoryRepository interface {
+	ProductRepository
+	ProductSlugRepository
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+	"time"
+)
+
+type ProductStatusRepository interface {
+	GetScheduled(ctx context.Context, until time.Time) ([]*models.Product, error)
+	UpdateStatus(ctx context.Context, product *models.Product, previousStatus string) (*models.Product, error)
+}
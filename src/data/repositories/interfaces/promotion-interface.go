@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type PromotionRepository interface {
+	GetPromoted(ctx context.Context) ([]*models.Product, error)
+	SetSalePrice(ctx context.Context, ID uuid.UUID, price *models.Money) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationRepository interface {
+	FindByOrder(ctx context.Context, orderID string) (*models.Reservation, error)
+	FindExpired(ctx context.Context, now time.Time) ([]*models.Reservation, error)
+	Extend(ctx context.Context, ID uuid.UUID, expiresAt time.Time) ([]*models.Store, error)
+	Pay(ctx context.Context, ID uuid.UUID, storeIDs []uuid.UUID) ([]*models.Store, error)
+	Release(ctx context.Context, ID uuid.UUID, status string) ([]*models.Store, error)
+}
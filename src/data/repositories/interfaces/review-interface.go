@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type ReviewRepository interface {
+	GetAll(ctx context.Context, filter *models.ReviewFilter, page int, size int) ([]*models.Review, error)
+	FindByID(ctx context.Context, ID uuid.UUID) (*models.Review, error)
+	FindByUser(ctx context.Context, productID uuid.UUID, userID string) (*models.Review, error)
+	Create(ctx context.Context, review *models.Review) (*models.Review, error)
+	UpdateStatus(ctx context.Context, review *models.Review) (*models.Review, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+	UpdateRating(ctx context.Context, productID uuid.UUID) (*models.ProductRating, error)
+}
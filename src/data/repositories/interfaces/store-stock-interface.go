@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StoreStockRepository interface {
+	CountAvailable(ctx context.Context, productID uuid.UUID, variantID uuid.NullUUID) (uint, error)
+	WriteOff(ctx context.Context, productID uuid.UUID, variantID uuid.NullUUID, quantity uint) ([]*models.Store, error)
+	LastStockChange(ctx context.Context, productIDs []uuid.UUID) (time.Time, error)
+}
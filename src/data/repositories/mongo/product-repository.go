@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"product/src/models"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,14 +39,29 @@ func (r *productRepository) collection() *mongo.Collection {
 }
 
 func (r *productRepository) aggregate(ctx context.Context, pipeline interface{}) (*models.Product, error) {
+	products, err := r.aggregateAll(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *models.Product
+	if len(products) > 0 {
+		product = products[0]
+	}
+
+	return product, nil
+}
+
+func (r *productRepository) aggregateAll(ctx context.Context, pipeline interface{}) ([]*models.Product, error) {
 	cursor, err := r.collection().Aggregate(ctx, pipeline)
 	if err != nil {
 		fmt.Println(err.Error())
 		return nil, err
 	}
 
+	defer cursor.Close(ctx)
+
 	products := []*models.Product{}
-	product := &models.Product{}
 
 	for cursor.Next(ctx) {
 		object := map[string]interface{}{}
@@ -55,7 +71,7 @@ func (r *productRepository) aggregate(ctx context.Context, pipeline interface{})
 			return nil, err
 		}
 
-		product, err = r.mapProduct(object)
+		product, err := r.mapProduct(object)
 		if err != nil {
 			return nil, err
 		}
@@ -63,18 +79,12 @@ func (r *productRepository) aggregate(ctx context.Context, pipeline interface{})
 		products = append(products, product)
 	}
 
-	product = nil
-
-	if len(products) > 0 {
-		product = products[0]
-	}
-
-	return product, nil
+	return products, nil
 }
 
-func (r *productRepository) find(ctx context.Context, filter interface{}, page int, size int) ([]*models.Product, error) {
+func (r *productRepository) find(ctx context.Context, filter interface{}, sort bson.D, page int, size int) ([]*models.Product, error) {
 	findOptions := options.FindOptions{}
-	findOptions.SetSort(bson.M{"name": 1})
+	findOptions.SetSort(sort)
 
 	page64 := int64(page)
 	size64 := int64(size)
@@ -145,15 +155,73 @@ func (r *productRepository) findOneAndUpdate(ctx context.Context, filter interfa
 	return result
 }
 
-func (r *productRepository) GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error) {
+func (r *productRepository) GetAll(ctx context.Context, productFilter *models.ProductFilter, page int, size int) ([]*models.Product, error) {
 	// filter := bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: name, Options: "i"}}}
 
-	filter := map[string]interface{}{}
-	if len(strings.TrimSpace(name)) > 0 {
-		filter = bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: name, Options: "i"}}}
+	filter := bson.M{}
+	if len(productFilter.IDs) > 0 {
+		IDs := bson.A{}
+		for _, ID := range productFilter.IDs {
+			IDs = append(IDs, ID.String())
+		}
+		filter["_id"] = bson.M{"$in": IDs}
+	}
+
+	name := strings.TrimSpace(productFilter.Name)
+	if len(name) > 0 {
+		regex := primitive.Regex{Pattern: name, Options: "i"}
+		filter["$or"] = bson.A{
+			bson.M{"name": regex},
+			bson.M{"translations": bson.M{"$elemMatch": bson.M{"locale": productFilter.Locale, "name": regex}}},
+		}
+	}
+
+	if len(productFilter.CategoryIDs) > 0 {
+		categories := bson.A{}
+		for _, categoryID := range productFilter.CategoryIDs {
+			categories = append(categories, categoryID.String())
+		}
+		filter["categories"] = bson.M{"$in": categories}
+	}
+
+	if productFilter.BrandID.Valid {
+		filter["brandid"] = productFilter.BrandID.UUID.String()
+	}
+
+	sku := strings.TrimSpace(productFilter.SKU)
+	if len(sku) > 0 {
+		filter["variants.sku"] = sku
 	}
 
-	return r.find(ctx, filter, page, size)
+	if len(productFilter.Status) > 0 {
+		filter["status"] = productFilter.Status
+	}
+
+	if len(productFilter.Attributes) > 0 {
+		attributes := bson.A{}
+		for _, attribute := range productFilter.Attributes {
+			attributes = append(attributes, bson.M{"attributes": bson.M{"$elemMatch": r.attributeFilter(attribute)}})
+		}
+		filter["$and"] = attributes
+	}
+
+	if productFilter.MinRating > 0 {
+		filter["rating"] = bson.M{"$gte": productFilter.MinRating}
+	}
+
+	priced := bson.A{}
+	if productFilter.PriceListID.Valid {
+		priced = append(priced, bson.M{"prices.pricelistid": productFilter.PriceListID.UUID.String()})
+	}
+	if len(productFilter.Currency) > 0 {
+		priced = append(priced, bson.M{"price.currency": productFilter.Currency})
+	}
+	if len(priced) > 0 {
+		and, _ := filter["$and"].(bson.A)
+		filter["$and"] = append(and, bson.M{"$or": priced})
+	}
+
+	return r.find(ctx, filter, r.sort(productFilter.Sort), page, size)
 }
 
 func (r *productRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Product, error) {
@@ -165,8 +233,49 @@ func (r *productRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models
 func (r *productRepository) FindBySlug(ctx context.Context, slug string) (*models.Product, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{"slug": slug, "deleted": false},
+			"$match": bson.M{
+				"$or":     bson.A{bson.M{"slug": slug}, bson.M{"translations.slug": slug}},
+				"deleted": false,
+			},
+		},
+		{
+			// a main slug wins over another product's translation slug
+			"$addFields": bson.M{"main_slug": bson.M{"$eq": bson.A{"$slug", slug}}},
+		},
+		{
+			"$sort": bson.D{{Key: "main_slug", Value: -1}, {Key: "_id", Value: 1}},
 		},
+		{
+			"$limit": 1,
+		},
+		{
+			"$project": bson.M{"main_slug": 0},
+		},
+	}
+	pipeline = append(pipeline, r.availability()...)
+
+	return r.aggregate(ctx, pipeline)
+}
+
+func (r *productRepository) GetFeed(ctx context.Context) ([]*models.Product, error) {
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"status":  models.ProductPublished,
+				"deleted": false,
+			},
+		},
+		{
+			"$sort": bson.M{"name": 1},
+		},
+	}
+	pipeline = append(pipeline, r.availability()...)
+
+	return r.aggregateAll(ctx, pipeline)
+}
+
+func (r *productRepository) availability() []bson.M {
+	return []bson.M{
 		{
 			"$lookup": bson.M{
 				"from":         "stores",
@@ -183,17 +292,107 @@ func (r *productRepository) FindBySlug(ctx context.Context, slug string) (*model
 						},
 					},
 				},
-				"as": "quantity",
+				"as": "available",
+			},
+		},
+		{
+			// products without components have no components.productid, so
+			// only bundles match stores here
+			"$lookup": bson.M{
+				"from":         "stores",
+				"localField":   "components.productid",
+				"foreignField": "product_id",
+				"pipeline": bson.A{
+					bson.M{
+						"$match": bson.M{
+							"deleted": false,
+							"sold":    false,
+							"booked_at": bson.M{
+								"$lte": time.Now().UTC(),
+							},
+						},
+					},
+				},
+				"as": "component_available",
 			},
 		},
 		{
 			"$addFields": bson.M{
-				"quantity": bson.M{"$size": "$quantity"},
+				"quantity": bson.M{
+					"$cond": bson.A{
+						bson.M{"$eq": bson.A{"$type", models.ProductBundle}},
+						r.bundleQuantity(),
+						bson.M{"$size": "$available"},
+					},
+				},
+				"variants": bson.M{
+					"$map": bson.M{
+						"input": bson.M{"$ifNull": bson.A{"$variants", bson.A{}}},
+						"as":    "variant",
+						"in": bson.M{
+							"$mergeObjects": bson.A{
+								"$$variant",
+								bson.M{
+									"quantity": bson.M{
+										"$size": bson.M{
+											"$filter": bson.M{
+												"input": "$available",
+												"as":    "store",
+												"cond":  bson.M{"$eq": bson.A{"$$store.variant_id", "$$variant.id"}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
+		{
+			"$project": bson.M{"available": 0, "component_available": 0},
+		},
 	}
+}
 
-	return r.aggregate(ctx, pipeline)
+func (r *productRepository) bundleQuantity() bson.M {
+	return bson.M{
+		"$ifNull": bson.A{
+			bson.M{
+				"$min": bson.M{
+					"$map": bson.M{
+						"input": "$components",
+						"as":    "component",
+						"in": bson.M{
+							"$floor": bson.M{
+								"$divide": bson.A{
+									bson.M{
+										"$size": bson.M{
+											"$filter": bson.M{
+												"input": "$component_available",
+												"as":    "store",
+												"cond": bson.M{
+													"$and": bson.A{
+														bson.M{"$eq": bson.A{"$$store.product_id", "$$component.productid"}},
+														bson.M{"$or": bson.A{
+															bson.M{"$eq": bson.A{"$$component.variantid", nil}},
+															bson.M{"$eq": bson.A{"$$store.variant_id", "$$component.variantid"}},
+														}},
+													},
+												},
+											},
+										},
+									},
+									"$$component.quantity",
+								},
+							},
+						},
+					},
+				},
+			},
+			0,
+		},
+	}
 }
 
 func (r *productRepository) FindByName(ctx context.Context, name string) (*models.Product, error) {
@@ -205,20 +404,62 @@ func (r *productRepository) FindByName(ctx context.Context, name string) (*model
 func (r *productRepository) Create(ctx context.Context, product *models.Product) (*models.Product, error) {
 	product.CreatedAt = time.Now().UTC()
 
+	price, err := r.money(product.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := r.variants(product.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := r.prices(product.Prices)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions, err := r.promotions(product.Promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	product.SalePrice = models.SalePrice(product.Promotions, time.Now().UTC())
+	salePrice, err := r.salePrice(product.SalePrice)
+	if err != nil {
+		return nil, err
+	}
+
 	fields := bson.M{
-		"_id":         product.ID.String(),
-		"name":        product.Name,
-		"slug":        product.Slug,
-		"description": product.Description,
-		"price":       product.Price,
-		"image":       product.Image,
-		"created_at":  product.CreatedAt,
-		"updated_at":  product.UpdatedAt,
-		"version":     product.Version,
-		"deleted":     product.Deleted,
-	}
-
-	_, err := r.collection().InsertOne(ctx, fields)
+		"_id":          product.ID.String(),
+		"name":         product.Name,
+		"slug":         product.Slug,
+		"description":  product.Description,
+		"price":        price,
+		"image":        product.Image,
+		"categories":   r.categories(product.Categories),
+		"brandid":      r.brand(product.BrandID),
+		"options":      product.Options,
+		"variants":     variants,
+		"prices":       prices,
+		"promotions":   promotions,
+		"sale_price":   salePrice,
+		"status":       product.Status,
+		"publish_at":   product.PublishAt,
+		"translations": product.Translations,
+		"attributes":   r.attributes(product.Attributes),
+		"type":         product.Type,
+		"components":   r.components(product.Components),
+		"relations":    r.relations(product.Relations),
+		"rating":       product.Rating,
+		"review_count": product.ReviewCount,
+		"created_at":   product.CreatedAt,
+		"updated_at":   product.UpdatedAt,
+		"version":      product.Version,
+		"deleted":      product.Deleted,
+	}
+
+	_, err = r.collection().InsertOne(ctx, fields)
 	if err != nil {
 		return nil, err
 	}
@@ -230,14 +471,9 @@ func (r *productRepository) Update(ctx context.Context, product *models.Product)
 	// product.Version++
 	product.UpdatedAt = time.Now().UTC()
 
-	fields := bson.M{
-		"name":        product.Name,
-		"slug":        product.Slug,
-		"description": product.Description,
-		"price":       product.Price,
-		"image":       product.Image,
-		"updated_at":  product.UpdatedAt,
-		"version":     product.Version,
+	fields, err := r.fields(product)
+	if err != nil {
+		return nil, err
 	}
 
 	filter := r.filterUpdate(product)
@@ -248,7 +484,7 @@ func (r *productRepository) Update(ctx context.Context, product *models.Product)
 	}
 
 	object := map[string]interface{}{}
-	err := result.Decode(object)
+	err = result.Decode(object)
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +497,154 @@ func (r *productRepository) Update(ctx context.Context, product *models.Product)
 	return modelProduct, err
 }
 
+// Patch writes only the top-level fields named in an RFC 7396 merge patch, so fields the patch does not
+// touch keep whatever the read model holds.
+func (r *productRepository) Patch(ctx context.Context, ID uuid.UUID, patch map[string]interface{}) (*models.Product, error) {
+	update, err := r.patchUpdate(ID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := r.filterUpdate(&models.Product{ID: ID})
+
+	findOneAndUpdateOptions := options.FindOneAndUpdateOptions{}
+	findOneAndUpdateOptions.SetReturnDocument(options.After)
+
+	result := r.collection().FindOneAndUpdate(ctx, filter, update, &findOneAndUpdateOptions)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, result.Err()
+	}
+
+	object := map[string]interface{}{}
+	err = result.Decode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapProduct(object)
+}
+
+// patchUpdate turns a merge patch into $set and $unset operators. Null members are unset and a partial
+// price object only touches the money members it carries.
+func (r *productRepository) patchUpdate(ID uuid.UUID, patch map[string]interface{}) (bson.M, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &models.Product{}
+	err = json.Unmarshal(data, product)
+	if err != nil {
+		return nil, err
+	}
+	product.ID = ID
+	product.UpdatedAt = time.Now().UTC()
+
+	fields, err := r.fields(product)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bson.M{"updated_at": product.UpdatedAt}
+	unset := bson.M{}
+	for key, value := range patch {
+		field, ok := fields[key]
+		if !ok || key == "updated_at" || key == "sale_price" {
+			continue
+		}
+
+		if value == nil {
+			unset[key] = ""
+			if key == "promotions" {
+				set["sale_price"] = nil
+			}
+			continue
+		}
+
+		if members, ok := value.(map[string]interface{}); ok && key == "price" {
+			price := field.(bson.M)
+			for member, memberValue := range members {
+				if _, ok := price[member]; !ok {
+					continue
+				}
+				if memberValue == nil {
+					unset["price."+member] = ""
+				} else {
+					set["price."+member] = price[member]
+				}
+			}
+			continue
+		}
+
+		set[key] = field
+		if key == "promotions" {
+			set["sale_price"] = fields["sale_price"]
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update, nil
+}
+
+func (r *productRepository) fields(product *models.Product) (bson.M, error) {
+	price, err := r.money(product.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := r.variants(product.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := r.prices(product.Prices)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions, err := r.promotions(product.Promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	product.SalePrice = models.SalePrice(product.Promotions, time.Now().UTC())
+	salePrice, err := r.salePrice(product.SalePrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{
+		"name":         product.Name,
+		"slug":         product.Slug,
+		"description":  product.Description,
+		"price":        price,
+		"image":        product.Image,
+		"categories":   r.categories(product.Categories),
+		"brandid":      r.brand(product.BrandID),
+		"options":      product.Options,
+		"variants":     variants,
+		"prices":       prices,
+		"promotions":   promotions,
+		"sale_price":   salePrice,
+		"status":       product.Status,
+		"publish_at":   product.PublishAt,
+		"translations": product.Translations,
+		"attributes":   r.attributes(product.Attributes),
+		"type":         product.Type,
+		"components":   r.components(product.Components),
+		"relations":    r.relations(product.Relations),
+		"updated_at":   product.UpdatedAt,
+		"version":      product.Version,
+	}, nil
+}
+
 func (r *productRepository) Delete(ctx context.Context, ID uuid.UUID) error {
 	filter := bson.M{"_id": ID.String()}
 
@@ -274,6 +658,28 @@ func (r *productRepository) Delete(ctx context.Context, ID uuid.UUID) error {
 	return nil
 }
 
+func (r *productRepository) Restore(ctx context.Context, ID uuid.UUID) (*models.Product, error) {
+	filter := bson.M{"_id": ID.String(), "deleted": true}
+
+	fields := bson.M{"deleted": false, "updated_at": time.Now().UTC()}
+
+	result := r.findOneAndUpdate(ctx, filter, fields)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, result.Err()
+	}
+
+	object := map[string]interface{}{}
+	err := result.Decode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapProduct(object)
+}
+
 func (r *productRepository) filterUpdate(product *models.Product) interface{} {
 	filter := bson.M{
 		"_id": product.ID.String(),
@@ -284,6 +690,258 @@ func (r *productRepository) filterUpdate(product *models.Product) interface{} {
 	return filter
 }
 
+func (r *productRepository) categories(categories []uuid.UUID) bson.A {
+	values := bson.A{}
+	for _, category := range categories {
+		values = append(values, category.String())
+	}
+
+	return values
+}
+
+func (r *productRepository) brand(brandID uuid.NullUUID) interface{} {
+	if !brandID.Valid {
+		return nil
+	}
+
+	return brandID.UUID.String()
+}
+
+func (r *productRepository) money(money models.Money) (bson.M, error) {
+	amount, err := primitive.ParseDecimal128(money.Amount.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{
+		"amount":   amount,
+		"currency": money.Currency,
+	}, nil
+}
+
+func (r *productRepository) GetPromoted(ctx context.Context) ([]*models.Product, error) {
+	pipeline := []bson.M{
+		{
+			"$match": bson.M{
+				"deleted": false,
+				"$or": bson.A{
+					bson.M{"promotions.ends_at": bson.M{"$gt": time.Now().UTC()}},
+					bson.M{"sale_price": bson.M{"$ne": nil}},
+				},
+			},
+		},
+		{
+			"$sort": bson.M{"name": 1},
+		},
+	}
+	pipeline = append(pipeline, r.availability()...)
+
+	return r.aggregateAll(ctx, pipeline)
+}
+
+func (r *productRepository) SetSalePrice(ctx context.Context, ID uuid.UUID, price *models.Money) error {
+	salePrice, err := r.salePrice(price)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": ID.String()}
+
+	fields := bson.M{"sale_price": salePrice}
+
+	result := r.findOneAndUpdate(ctx, filter, fields)
+	if result.Err() != nil {
+		return result.Err()
+	}
+
+	return nil
+}
+
+func (r *productRepository) SetRating(ctx context.Context, rating *models.ProductRating) (*models.Product, error) {
+	filter := bson.M{"_id": rating.ProductID.String()}
+
+	fields := bson.M{"rating": rating.Rating, "review_count": rating.ReviewCount}
+
+	result := r.findOneAndUpdate(ctx, filter, fields)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, result.Err()
+	}
+
+	object := map[string]interface{}{}
+	err := result.Decode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapProduct(object)
+}
+
+func (r *productRepository) sort(sort string) bson.D {
+	switch sort {
+	case models.ProductSortRating:
+		return bson.D{{Key: "rating", Value: 1}, {Key: "name", Value: 1}}
+	case models.ProductSortRatingDesc:
+		return bson.D{{Key: "rating", Value: -1}, {Key: "name", Value: 1}}
+	default:
+		return bson.D{{Key: "name", Value: 1}}
+	}
+}
+
+func (r *productRepository) salePrice(price *models.Money) (interface{}, error) {
+	if price == nil {
+		return nil, nil
+	}
+
+	return r.money(*price)
+}
+
+func (r *productRepository) promotions(promotions []*models.ProductPromotion) (bson.A, error) {
+	values := bson.A{}
+	for _, promotion := range promotions {
+		money, err := r.money(promotion.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		var priceListID interface{}
+		if promotion.PriceListID.Valid {
+			priceListID = promotion.PriceListID.UUID.String()
+		}
+
+		values = append(values, bson.M{
+			"id":          promotion.ID.String(),
+			"pricelistid": priceListID,
+			"price":       money,
+			"starts_at":   promotion.StartsAt,
+			"ends_at":     promotion.EndsAt,
+		})
+	}
+
+	return values, nil
+}
+
+func (r *productRepository) prices(prices []*models.ProductPrice) (bson.A, error) {
+	values := bson.A{}
+	for _, price := range prices {
+		money, err := r.money(price.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, bson.M{
+			"pricelistid": price.PriceListID.String(),
+			"price":       money,
+		})
+	}
+
+	return values, nil
+}
+
+func (r *productRepository) attributes(attributes []*models.ProductAttribute) bson.A {
+	values := bson.A{}
+	for _, attribute := range attributes {
+		values = append(values, bson.M{
+			"attributeid": attribute.AttributeID.String(),
+			"code":        attribute.Code,
+			"type":        attribute.Type,
+			"value":       attribute.Value,
+		})
+	}
+
+	return values
+}
+
+func (r *productRepository) components(components []*models.ProductComponent) bson.A {
+	values := bson.A{}
+	for _, component := range components {
+		var variantID interface{}
+		if component.VariantID.Valid {
+			variantID = component.VariantID.UUID.String()
+		}
+
+		values = append(values, bson.M{
+			"productid": component.ProductID.String(),
+			"variantid": variantID,
+			"quantity":  component.Quantity,
+		})
+	}
+
+	return values
+}
+
+func (r *productRepository) relations(relations []*models.ProductRelation) bson.A {
+	values := bson.A{}
+	for _, relation := range relations {
+		values = append(values, bson.M{
+			"productid": relation.ProductID.String(),
+			"type":      relation.Type,
+			"position":  relation.Position,
+		})
+	}
+
+	return values
+}
+
+func (r *productRepository) attributeFilter(attribute *models.AttributeFilter) bson.M {
+	if attribute.Range() {
+		value := bson.M{}
+		if attribute.Min != nil {
+			value["$gte"] = *attribute.Min
+		}
+		if attribute.Max != nil {
+			value["$lte"] = *attribute.Max
+		}
+
+		return bson.M{"code": attribute.Code, "type": models.AttributeNumber, "value": value}
+	}
+
+	values := bson.A{attribute.Value}
+	if number, err := strconv.ParseFloat(attribute.Value, 64); err == nil {
+		values = append(values, number)
+	}
+	if boolean, err := strconv.ParseBool(attribute.Value); err == nil {
+		values = append(values, boolean)
+	}
+
+	return bson.M{"code": attribute.Code, "value": bson.M{"$in": values}}
+}
+
+func (r *productRepository) variants(variants []*models.ProductVariant) (bson.A, error) {
+	values := bson.A{}
+	for _, variant := range variants {
+		var price interface{}
+		if variant.Price != nil {
+			money, err := r.money(*variant.Price)
+			if err != nil {
+				return nil, err
+			}
+			price = money
+		}
+
+		prices, err := r.prices(variant.Prices)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, bson.M{
+			"id":         variant.ID.String(),
+			"product_id": variant.ProductID.String(),
+			"sku":        variant.SKU,
+			"options":    variant.Options,
+			"price":      price,
+			"prices":     prices,
+			"created_at": variant.CreatedAt,
+			"updated_at": variant.UpdatedAt,
+			"version":    variant.Version,
+		})
+	}
+
+	return values, nil
+}
+
 func (r *productRepository) mapProduct(object map[string]interface{}) (*models.Product, error) {
 	jsonStr, err := json.Marshal(object)
 	if err != nil {
@@ -302,5 +960,9 @@ func (r *productRepository) mapProduct(object map[string]interface{}) (*models.P
 
 	product.ID = ID
 
+	for _, variant := range product.Variants {
+		variant.ProductID = ID
+	}
+
 	return &product, nil
 }
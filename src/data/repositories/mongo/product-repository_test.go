@@ -0,0 +1,122 @@
+package mongo_repository
+
+import (
+	"encoding/json"
+	"product/src/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMoneyDecimal128RoundTrip(t *testing.T) {
+	r := &productRepository{}
+
+	tests := []struct {
+		name   string
+		amount string
+	}{
+		{"cents", "10.50"},
+		{"float unsafe", "0.1"},
+		{"large", "99999999.99"},
+		{"zero", "0"},
+		{"negative", "-1.25"},
+		{"many digits", "1234.5678"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			money := models.NewMoney(decimal.RequireFromString(test.amount), "BRL")
+
+			doc, err := r.money(money)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := bson.Marshal(bson.M{"price": doc})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			object := map[string]interface{}{}
+			err = bson.Unmarshal(data, &object)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jsonStr, err := json.Marshal(object)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result struct {
+				Price models.Money `json:"price"`
+			}
+			err = json.Unmarshal(jsonStr, &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !result.Price.Amount.Equal(money.Amount) {
+				t.Errorf("amount = %s, want %s", result.Price.Amount, money.Amount)
+			}
+
+			if result.Price.Currency != money.Currency {
+				t.Errorf("currency = %s, want %s", result.Price.Currency, money.Currency)
+			}
+		})
+	}
+}
+
+func TestPatchUpdate(t *testing.T) {
+	r := &productRepository{}
+
+	tests := []struct {
+		name  string
+		patch string
+		set   []string
+		unset []string
+	}{
+		{"single field", `{"name":"Notebook","version":4}`, []string{"name", "version", "updated_at"}, nil},
+		{"partial price", `{"price":{"amount":"9.99"}}`, []string{"price.amount", "updated_at"}, nil},
+		{"null removes field", `{"image":null}`, []string{"updated_at"}, []string{"image"}},
+		{"promotions refresh sale price", `{"promotions":[]}`, []string{"promotions", "sale_price", "updated_at"}, nil},
+		{"unknown field ignored", `{"id":"7c1ad5b5-3a2b-4f1e-9d1c-2f0f4a9b8e11","quantity":3}`, []string{"updated_at"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			patch := map[string]interface{}{}
+			err := json.Unmarshal([]byte(test.patch), &patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			update, err := r.patchUpdate(uuid.New(), patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			set := update["$set"].(bson.M)
+			if len(set) != len(test.set) {
+				t.Errorf("$set = %v, want keys %v", set, test.set)
+			}
+			for _, key := range test.set {
+				if _, ok := set[key]; !ok {
+					t.Errorf("$set is missing %s", key)
+				}
+			}
+
+			unset, _ := update["$unset"].(bson.M)
+			if len(unset) != len(test.unset) {
+				t.Errorf("$unset = %v, want keys %v", unset, test.unset)
+			}
+			for _, key := range test.unset {
+				if _, ok := unset[key]; !ok {
+					t.Errorf("$unset is missing %s", key)
+				}
+			}
+		})
+	}
+}
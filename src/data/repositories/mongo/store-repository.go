@@ -121,32 +121,14 @@ func (r *storeRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.S
 	return r.findOne(ctx, filter)
 }
 
-func (r *storeRepository) Book(ctx context.Context, productID uuid.UUID, quantity uint) ([]*models.Store, error) {
-	// filter := map[string]interface{}{
-	// 	"product_id": productID.String(),
-	// 	"sold":       false,
-	// 	"booked_at":  bson.M{"$lte": time.Now().UTC()},
-	// }
-
-	// return r.find(ctx, filter, quantity)
-
-	return nil, errors.New("not implemented")
+func (r *storeRepository) BookOrder(ctx context.Context, reservation *models.Reservation) ([]*models.Store, []*models.StoreBooking, error) {
+	return nil, nil, errors.New("not implemented")
 }
 
 func (r *storeRepository) Create(ctx context.Context, stores []*models.Store) error {
 	var docs []interface{}
 	for _, store := range stores {
-		doc := bson.M{
-			"_id":        store.ID.String(),
-			"product_id": store.ProductID.String(),
-			"created_at": store.CreatedAt,
-			"booked_at":  time.Time{},
-			"sold":       false,
-			"version":    0,
-			"deleted":    false,
-		}
-
-		docs = append(docs, doc)
+		docs = append(docs, r.document(store))
 	}
 
 	_, err := r.collection().InsertMany(context.TODO(), docs)
@@ -168,10 +150,11 @@ func (r *storeRepository) Update(ctx context.Context, stores []*models.Store) ([
 		})
 		model.SetUpdate(bson.M{
 			"$set": bson.M{
-				"booked_at":  store.BookedAt,
-				"sold":       store.Sold,
-				"updated_at": store.UpdatedAt,
-				"version":    store.Version,
+				"reservation_id": r.nullUUID(store.ReservationID),
+				"booked_at":      store.BookedAt,
+				"sold":           store.Sold,
+				"updated_at":     store.UpdatedAt,
+				"version":        store.Version,
 			},
 		})
 
@@ -200,6 +183,27 @@ func (r *storeRepository) Delete(ctx context.Context, ID uuid.UUID) error {
 	return nil
 }
 
+func (r *storeRepository) document(store *models.Store) bson.M {
+	return bson.M{
+		"_id":        store.ID.String(),
+		"product_id": store.ProductID.String(),
+		"variant_id": r.nullUUID(store.VariantID),
+		"created_at": store.CreatedAt,
+		"booked_at":  time.Time{},
+		"sold":       false,
+		"version":    0,
+		"deleted":    false,
+	}
+}
+
+func (r *storeRepository) nullUUID(ID uuid.NullUUID) interface{} {
+	if !ID.Valid {
+		return nil
+	}
+
+	return ID.UUID.String()
+}
+
 func (r *storeRepository) mapStore(object map[string]interface{}) (*models.Store, error) {
 	jsonStr, err := json.Marshal(object)
 	if err != nil {
@@ -223,5 +227,29 @@ func (r *storeRepository) mapStore(object map[string]interface{}) (*models.Store
 	}
 	store.ProductID = productID
 
+	store.VariantID, err = r.parseNullUUID(object["variant_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	store.ReservationID, err = r.parseNullUUID(object["reservation_id"])
+	if err != nil {
+		return nil, err
+	}
+
 	return &store, nil
 }
+
+func (r *storeRepository) parseNullUUID(value interface{}) (uuid.NullUUID, error) {
+	text, ok := value.(string)
+	if !ok {
+		return uuid.NullUUID{}, nil
+	}
+
+	ID, err := uuid.Parse(text)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	return uuid.NullUUID{UUID: ID, Valid: true}, nil
+}
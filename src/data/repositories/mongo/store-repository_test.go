@@ -0,0 +1,65 @@
+package mongo_repository
+
+import (
+	"product/src/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStoreDocumentRoundTrip(t *testing.T) {
+	r := &storeRepository{}
+
+	tests := []struct {
+		name      string
+		variantID uuid.NullUUID
+	}{
+		{"with variant", uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+		{"without variant", uuid.NullUUID{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &models.Store{
+				ID:        uuid.New(),
+				ProductID: uuid.New(),
+				VariantID: test.variantID,
+				CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+			}
+
+			data, err := bson.Marshal(r.document(store))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			object := map[string]interface{}{}
+			err = bson.Unmarshal(data, &object)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := r.mapStore(object)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.ID != store.ID {
+				t.Errorf("id = %s, want %s", result.ID, store.ID)
+			}
+
+			if result.ProductID != store.ProductID {
+				t.Errorf("product id = %s, want %s", result.ProductID, store.ProductID)
+			}
+
+			if result.VariantID != store.VariantID {
+				t.Errorf("variant id = %v, want %v", result.VariantID, store.VariantID)
+			}
+
+			if result.ReservationID.Valid {
+				t.Errorf("reservation id = %v, want none", result.ReservationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type attributeRepository struct {
+	database *sql.DB
+}
+
+func NewAttributeRepository(database *sql.DB) *attributeRepository {
+	return &attributeRepository{
+		database: database,
+	}
+}
+
+const attributeColumns = `
+		id,
+		code,
+		name,
+		type,
+		"values",
+		COALESCE(unit, '') unit,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version`
+
+func (r *attributeRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.Attribute, error) {
+	rows, err := r.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := []*models.Attribute{}
+	for rows.Next() {
+		var attribute models.Attribute
+		err = rows.Scan(
+			&attribute.ID,
+			&attribute.Code,
+			&attribute.Name,
+			&attribute.Type,
+			jsonColumn{value: &attribute.Values},
+			&attribute.Unit,
+			&attribute.CreatedAt,
+			&attribute.UpdatedAt,
+			&attribute.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, &attribute)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+func (r *attributeRepository) queryRow(ctx context.Context, query string, args ...interface{}) (*models.Attribute, error) {
+	var attribute models.Attribute
+	row := r.database.QueryRowContext(ctx, query, args...)
+	if err := row.Scan(
+		&attribute.ID,
+		&attribute.Code,
+		&attribute.Name,
+		&attribute.Type,
+		jsonColumn{value: &attribute.Values},
+		&attribute.Unit,
+		&attribute.CreatedAt,
+		&attribute.UpdatedAt,
+		&attribute.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attribute, nil
+}
+
+func (r *attributeRepository) GetAll(ctx context.Context) ([]*models.Attribute, error) {
+	return r.query(ctx, `SELECT `+attributeColumns+`
+		FROM attributes
+		WHERE deleted = false
+		ORDER BY code ASC`)
+}
+
+func (r *attributeRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Attribute, error) {
+	return r.queryRow(ctx, `SELECT `+attributeColumns+`
+		FROM attributes
+		WHERE id = $1
+		AND deleted = false`, ID)
+}
+
+func (r *attributeRepository) FindByCode(ctx context.Context, code string) (*models.Attribute, error) {
+	return r.queryRow(ctx, `SELECT `+attributeColumns+`
+		FROM attributes
+		WHERE code = $1
+		AND deleted = false`, code)
+}
+
+func (r *attributeRepository) InUse(ctx context.Context, ID uuid.UUID) (bool, error) {
+	var inUse bool
+	err := r.database.QueryRowContext(ctx,
+		`SELECT EXISTS (
+			SELECT 1 
+			FROM product_attributes 
+			INNER JOIN products ON products.id = product_attributes.product_id
+			WHERE product_attributes.attribute_id = $1
+			AND products.deleted = false
+		)`, ID).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+func (r *attributeRepository) Create(ctx context.Context, attribute *models.Attribute) (*models.Attribute, error) {
+	sql := `INSERT INTO attributes (id, code, name, type, "values", unit, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.database.ExecContext(ctx, sql,
+		attribute.ID,
+		attribute.Code,
+		attribute.Name,
+		attribute.Type,
+		jsonColumn{value: attribute.Values, empty: "[]"},
+		attribute.Unit,
+		attribute.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return attribute, nil
+}
+
+func (r *attributeRepository) Update(ctx context.Context, attribute *models.Attribute) (*models.Attribute, error) {
+	sql := `UPDATE attributes SET code = $1, name = $2, type = $3, "values" = $4, unit = $5, updated_at = $6, version = $7 WHERE id = $8 and version = ($7-1)`
+
+	attribute.Version++
+	attribute.UpdatedAt = time.Now().UTC()
+	_, err := r.database.ExecContext(ctx, sql,
+		attribute.Code,
+		attribute.Name,
+		attribute.Type,
+		jsonColumn{value: attribute.Values, empty: "[]"},
+		attribute.Unit,
+		attribute.UpdatedAt,
+		attribute.Version,
+		attribute.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return attribute, nil
+}
+
+func (r *attributeRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := r.database.ExecContext(ctx, "UPDATE attributes SET deleted = true WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type brandRepository struct {
+	database *sql.DB
+}
+
+func NewBrandRepository(database *sql.DB) *brandRepository {
+	return &brandRepository{
+		database: database,
+	}
+}
+
+const brandColumns = `
+		id,
+		name,
+		slug,
+		COALESCE(logo, '') logo,
+		COALESCE(description, '') description,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version`
+
+func (r *brandRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.Brand, error) {
+	rows, err := r.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := []*models.Brand{}
+	for rows.Next() {
+		var brand models.Brand
+		err = rows.Scan(
+			&brand.ID,
+			&brand.Name,
+			&brand.Slug,
+			&brand.Logo,
+			&brand.Description,
+			&brand.CreatedAt,
+			&brand.UpdatedAt,
+			&brand.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		brands = append(brands, &brand)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+func (r *brandRepository) queryRow(ctx context.Context, query string, args ...interface{}) (*models.Brand, error) {
+	var brand models.Brand
+	row := r.database.QueryRowContext(ctx, query, args...)
+	if err := row.Scan(
+		&brand.ID,
+		&brand.Name,
+		&brand.Slug,
+		&brand.Logo,
+		&brand.Description,
+		&brand.CreatedAt,
+		&brand.UpdatedAt,
+		&brand.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &brand, nil
+}
+
+func (r *brandRepository) GetAll(ctx context.Context) ([]*models.Brand, error) {
+	return r.query(ctx, `SELECT `+brandColumns+`
+		FROM brands
+		WHERE deleted = false
+		ORDER BY name ASC`)
+}
+
+func (r *brandRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Brand, error) {
+	return r.queryRow(ctx, `SELECT `+brandColumns+`
+		FROM brands
+		WHERE id = $1
+		AND deleted = false`, ID)
+}
+
+func (r *brandRepository) FindBySlug(ctx context.Context, slug string) (*models.Brand, error) {
+	return r.queryRow(ctx, `SELECT `+brandColumns+`
+		FROM brands
+		WHERE slug = $1
+		AND deleted = false`, slug)
+}
+
+func (r *brandRepository) InUse(ctx context.Context, ID uuid.UUID) (bool, error) {
+	var inUse bool
+	err := r.database.QueryRowContext(ctx,
+		`SELECT EXISTS (
+			SELECT 1 
+			FROM products 
+			WHERE brand_id = $1
+			AND deleted = false
+		)`, ID).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+func (r *brandRepository) Create(ctx context.Context, brand *models.Brand) (*models.Brand, error) {
+	sql := "INSERT INTO brands (id, name, slug, logo, description, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		brand.ID,
+		brand.Name,
+		brand.Slug,
+		brand.Logo,
+		brand.Description,
+		brand.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+func (r *brandRepository) Update(ctx context.Context, brand *models.Brand) (*models.Brand, error) {
+	sql := "UPDATE brands SET name = $1, slug = $2, logo = $3, description = $4, updated_at = $5, version = $6 WHERE id = $7 and version = ($6-1)"
+
+	brand.Version++
+	brand.UpdatedAt = time.Now().UTC()
+	_, err := r.database.ExecContext(ctx, sql,
+		brand.Name,
+		brand.Slug,
+		brand.Logo,
+		brand.Description,
+		brand.UpdatedAt,
+		brand.Version,
+		brand.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+func (r *brandRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := r.database.ExecContext(ctx, "UPDATE brands SET deleted = true WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type categoryRepository struct {
+	database *sql.DB
+}
+
+func NewCategoryRepository(database *sql.DB) *categoryRepository {
+	return &categoryRepository{
+		database: database,
+	}
+}
+
+const categoryColumns = `
+		id,
+		parent_id,
+		name,
+		slug,
+		COALESCE(description, '') description,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version`
+
+func (r *categoryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.Category, error) {
+	rows, err := r.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*models.Category{}
+	for rows.Next() {
+		var category models.Category
+		err = rows.Scan(
+			&category.ID,
+			&category.ParentID,
+			&category.Name,
+			&category.Slug,
+			&category.Description,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, &category)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) queryRow(ctx context.Context, query string, args ...interface{}) (*models.Category, error) {
+	var category models.Category
+	row := r.database.QueryRowContext(ctx, query, args...)
+	if err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.Slug,
+		&category.Description,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context) ([]*models.Category, error) {
+	return r.query(ctx, `SELECT `+categoryColumns+`
+		FROM categories
+		WHERE deleted = false
+		ORDER BY name ASC`)
+}
+
+func (r *categoryRepository) GetChildren(ctx context.Context, parentID uuid.UUID) ([]*models.Category, error) {
+	return r.query(ctx, `SELECT `+categoryColumns+`
+		FROM categories
+		WHERE parent_id = $1
+		AND deleted = false
+		ORDER BY name ASC`, parentID)
+}
+
+func (r *categoryRepository) GetDescendantIDs(ctx context.Context, ID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.database.QueryContext(ctx,
+		`WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = $1 AND deleted = false
+			UNION
+			SELECT categories.id
+			FROM categories
+			INNER JOIN tree ON categories.parent_id = tree.id
+			WHERE categories.deleted = false
+		)
+		SELECT id FROM tree`, ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	IDs := []uuid.UUID{}
+	for rows.Next() {
+		var categoryID uuid.UUID
+		err = rows.Scan(&categoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		IDs = append(IDs, categoryID)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return IDs, nil
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Category, error) {
+	return r.queryRow(ctx, `SELECT `+categoryColumns+`
+		FROM categories
+		WHERE id = $1
+		AND deleted = false`, ID)
+}
+
+func (r *categoryRepository) FindBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	return r.queryRow(ctx, `SELECT `+categoryColumns+`
+		FROM categories
+		WHERE slug = $1
+		AND deleted = false`, slug)
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *models.Category) (*models.Category, error) {
+	sql := "INSERT INTO categories (id, parent_id, name, slug, description, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		category.ID,
+		category.ParentID,
+		category.Name,
+		category.Slug,
+		category.Description,
+		category.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *models.Category) (*models.Category, error) {
+	sql := "UPDATE categories SET parent_id = $1, name = $2, slug = $3, description = $4, updated_at = $5, version = $6 WHERE id = $7 and version = ($6-1)"
+
+	category.Version++
+	category.UpdatedAt = time.Now().UTC()
+	_, err := r.database.ExecContext(ctx, sql,
+		category.ParentID,
+		category.Name,
+		category.Slug,
+		category.Description,
+		category.UpdatedAt,
+		category.Version,
+		category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := r.database.ExecContext(ctx, "UPDATE categories SET deleted = true WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package postgres_repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type jsonColumn struct {
+	value interface{}
+	empty string
+}
+
+func (c jsonColumn) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, c.value)
+	case string:
+		return json.Unmarshal([]byte(data), c.value)
+	default:
+		return fmt.Errorf("unsupported json column type: %T", src)
+	}
+}
+
+func (c jsonColumn) Value() (driver.Value, error) {
+	data, err := json.Marshal(c.value)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(data) == "null" {
+		return c.empty, nil
+	}
+
+	return string(data), nil
+}
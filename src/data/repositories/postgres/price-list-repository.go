@@ -0,0 +1,169 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type priceListRepository struct {
+	database *sql.DB
+}
+
+func NewPriceListRepository(database *sql.DB) *priceListRepository {
+	return &priceListRepository{
+		database: database,
+	}
+}
+
+const priceListColumns = `
+		id,
+		code,
+		currency,
+		channel,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version`
+
+func (r *priceListRepository) queryRow(ctx context.Context, query string, args ...interface{}) (*models.PriceList, error) {
+	var priceList models.PriceList
+	row := r.database.QueryRowContext(ctx, query, args...)
+	if err := row.Scan(
+		&priceList.ID,
+		&priceList.Code,
+		&priceList.Currency,
+		&priceList.Channel,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+		&priceList.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &priceList, nil
+}
+
+func (r *priceListRepository) GetAll(ctx context.Context) ([]*models.PriceList, error) {
+	rows, err := r.database.QueryContext(ctx, `SELECT `+priceListColumns+`
+		FROM price_lists
+		WHERE deleted = false
+		ORDER BY code ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	priceLists := []*models.PriceList{}
+	for rows.Next() {
+		var priceList models.PriceList
+		err = rows.Scan(
+			&priceList.ID,
+			&priceList.Code,
+			&priceList.Currency,
+			&priceList.Channel,
+			&priceList.CreatedAt,
+			&priceList.UpdatedAt,
+			&priceList.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		priceLists = append(priceLists, &priceList)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return priceLists, nil
+}
+
+func (r *priceListRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.PriceList, error) {
+	return r.queryRow(ctx, `SELECT `+priceListColumns+`
+		FROM price_lists
+		WHERE id = $1
+		AND deleted = false`, ID)
+}
+
+func (r *priceListRepository) FindByCode(ctx context.Context, code string) (*models.PriceList, error) {
+	return r.queryRow(ctx, `SELECT `+priceListColumns+`
+		FROM price_lists
+		WHERE code = $1
+		AND deleted = false`, code)
+}
+
+func (r *priceListRepository) FindByCurrency(ctx context.Context, currency string, channel string) (*models.PriceList, error) {
+	return r.queryRow(ctx, `SELECT `+priceListColumns+`
+		FROM price_lists
+		WHERE currency = $1
+		AND (channel = $2 OR channel = '')
+		AND deleted = false
+		ORDER BY channel DESC
+		LIMIT 1`, currency, channel)
+}
+
+func (r *priceListRepository) InUse(ctx context.Context, ID uuid.UUID) (bool, error) {
+	var inUse bool
+	err := r.database.QueryRowContext(ctx,
+		`SELECT EXISTS (
+			SELECT 1 
+			FROM product_prices 
+			INNER JOIN products ON products.id = product_prices.product_id
+			WHERE product_prices.price_list_id = $1
+			AND products.deleted = false
+		)`, ID).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+func (r *priceListRepository) Create(ctx context.Context, priceList *models.PriceList) (*models.PriceList, error) {
+	sql := "INSERT INTO price_lists (id, code, currency, channel, created_at) VALUES ($1, $2, $3, $4, $5)"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		priceList.ID,
+		priceList.Code,
+		priceList.Currency,
+		priceList.Channel,
+		priceList.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+func (r *priceListRepository) Update(ctx context.Context, priceList *models.PriceList) (*models.PriceList, error) {
+	sql := "UPDATE price_lists SET code = $1, currency = $2, channel = $3, updated_at = $4, version = $5 WHERE id = $6 and version = ($5-1)"
+
+	priceList.Version++
+	priceList.UpdatedAt = time.Now().UTC()
+	_, err := r.database.ExecContext(ctx, sql,
+		priceList.Code,
+		priceList.Currency,
+		priceList.Channel,
+		priceList.UpdatedAt,
+		priceList.Version,
+		priceList.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+func (r *priceListRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := r.database.ExecContext(ctx, "UPDATE price_lists SET deleted = true WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type productImportRepository struct {
+	database *sql.DB
+}
+
+func NewProductImportRepository(database *sql.DB) *productImportRepository {
+	return &productImportRepository{
+		database: database,
+	}
+}
+
+func (r *productImportRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.ProductImport, error) {
+	var productImport models.ProductImport
+	row := r.database.QueryRowContext(ctx,
+		`SELECT
+			id,
+			format,
+			status,
+			total,
+			succeeded,
+			failed,
+			report,
+			COALESCE(created_by, '') created_by,
+			created_at,
+			COALESCE(finished_at, '0001-01-01 00:00:00+00') finished_at
+		FROM product_imports
+		WHERE id = $1`, ID)
+	if err := row.Scan(
+		&productImport.ID,
+		&productImport.Format,
+		&productImport.Status,
+		&productImport.Total,
+		&productImport.Succeeded,
+		&productImport.Failed,
+		jsonColumn{value: &productImport.Rows},
+		&productImport.CreatedBy,
+		&productImport.CreatedAt,
+		&productImport.FinishedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &productImport, nil
+}
+
+func (r *productImportRepository) Create(ctx context.Context, productImport *models.ProductImport) (*models.ProductImport, error) {
+	sql := "INSERT INTO product_imports (id, format, status, total, created_by, created_at) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		productImport.ID,
+		productImport.Format,
+		productImport.Status,
+		productImport.Total,
+		productImport.CreatedBy,
+		productImport.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return productImport, nil
+}
+
+func (r *productImportRepository) Update(ctx context.Context, productImport *models.ProductImport) (*models.ProductImport, error) {
+	sql := "UPDATE product_imports SET status = $1, succeeded = $2, failed = $3, report = $4, finished_at = $5 WHERE id = $6"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		productImport.Status,
+		productImport.Succeeded,
+		productImport.Failed,
+		jsonColumn{value: productImport.Rows, empty: "[]"},
+		r.finishedAt(productImport),
+		productImport.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return productImport, nil
+}
+
+func (r *productImportRepository) FailRunning(ctx context.Context, finishedAt time.Time) (int64, error) {
+	sql := "UPDATE product_imports SET status = $1, finished_at = $2 WHERE status = $3"
+
+	result, err := r.database.ExecContext(ctx, sql,
+		models.ImportFailed,
+		finishedAt,
+		models.ImportRunning)
+	if err != nil {
+		// a database that was never migrated has no imports to fail
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "42P01" {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *productImportRepository) finishedAt(productImport *models.ProductImport) sql.NullTime {
+	if productImport.FinishedAt.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: productImport.FinishedAt, Valid: true}
+}
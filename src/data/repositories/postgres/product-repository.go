@@ -3,10 +3,14 @@ package postgres_repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"product/src/models"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
 type productRepository struct {
@@ -19,23 +23,238 @@ func NewProductRepository(database *sql.DB) *productRepository {
 	}
 }
 
-func (r *productRepository) GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error) {
+const productPricesColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'pricelistid', product_prices.price_list_id,
+				'price', json_build_object('amount', product_prices.price::text, 'currency', price_lists.currency)))
+			FROM product_prices
+			INNER JOIN price_lists ON price_lists.id = product_prices.price_list_id
+			WHERE product_prices.product_id = products.id
+			AND price_lists.deleted = false
+		), '[]') prices`
+
+const productPromotionsColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', product_promotions.id,
+				'pricelistid', product_promotions.price_list_id,
+				'price', json_build_object('amount', product_promotions.price::text, 'currency', COALESCE(price_lists.currency, products.currency)),
+				'starts_at', product_promotions.starts_at,
+				'ends_at', product_promotions.ends_at) ORDER BY product_promotions.starts_at)
+			FROM product_promotions
+			LEFT JOIN price_lists ON price_lists.id = product_promotions.price_list_id
+			WHERE product_promotions.product_id = products.id
+			AND (price_lists.id IS NULL OR price_lists.deleted = false)
+		), '[]') promotions`
+
+const productVariantPricesColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'pricelistid', product_variant_prices.price_list_id,
+				'price', json_build_object('amount', product_variant_prices.price::text, 'currency', price_lists.currency)))
+			FROM product_variant_prices
+			INNER JOIN price_lists ON price_lists.id = product_variant_prices.price_list_id
+			WHERE product_variant_prices.variant_id = product_variants.id
+			AND price_lists.deleted = false
+		), '[]') prices`
+
+const productTranslationsColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'locale', product_translations.locale,
+				'name', product_translations.name,
+				'slug', product_translations.slug,
+				'description', COALESCE(product_translations.description, '')) ORDER BY product_translations.locale)
+			FROM product_translations
+			WHERE product_translations.product_id = products.id
+		), '[]') translations`
+
+const productAttributesColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'attributeid', product_attributes.attribute_id,
+				'code', attributes.code,
+				'type', attributes.type,
+				'value', product_attributes.value) ORDER BY attributes.code)
+			FROM product_attributes
+			INNER JOIN attributes ON attributes.id = product_attributes.attribute_id
+			WHERE product_attributes.product_id = products.id
+			AND attributes.deleted = false
+		), '[]') attributes`
+
+const productComponentsColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'productid', product_components.product_id,
+				'variantid', product_components.variant_id,
+				'quantity', product_components.quantity))
+			FROM product_components
+			WHERE product_components.bundle_id = products.id
+		), '[]') components`
+
+const productRelationsColumn = `COALESCE((
+			SELECT json_agg(json_build_object(
+				'productid', product_relations.related_id,
+				'type', product_relations.type,
+				'position', product_relations.position) ORDER BY product_relations.type, product_relations.position)
+			FROM product_relations
+			INNER JOIN products related ON related.id = product_relations.related_id
+			WHERE product_relations.product_id = products.id
+			AND related.deleted = false
+		), '[]') relations`
+
+const productAvailableColumn = `CASE WHEN products.type = 'bundle' THEN (
+			SELECT COALESCE(MIN((
+				SELECT COUNT(stores.id)
+				FROM stores
+				WHERE stores.productid = product_components.product_id
+				AND (product_components.variant_id IS NULL OR stores.variantid = product_components.variant_id)
+				AND stores.deleted = false
+				AND stores.sold = false
+				AND stores.booked_at <= NOW()::timestamptz
+			) / product_components.quantity), 0)
+			FROM product_components
+			WHERE product_components.bundle_id = products.id
+		) ELSE (
+			SELECT COUNT(productid) 
+			FROM stores 
+			WHERE productid = products.id 
+			AND stores.deleted = false 
+			AND sold = false
+			AND booked_at <= NOW()::timestamptz
+		) END`
+
+const productColumns = `
+		id, 
+		name, 
+		slug, 
+		description, 
+		price,
+		currency,
+		image,
+		created_at, 
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at, 
+		version,
+		options,
+		status,
+		COALESCE(publish_at, '0001-01-01 00:00:00+00') publish_at,
+		brand_id,
+		type,
+		rating,
+		review_count,
+		` + productCategoriesColumn + `,
+		` + productPricesColumn + `,
+		` + productPromotionsColumn + `,
+		` + productTranslationsColumn + `,
+		` + productAttributesColumn + `,
+		` + productComponentsColumn + `,
+		` + productRelationsColumn
+
+type productScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *productRepository) scanProduct(row productScanner, extra ...interface{}) (*models.Product, error) {
+	var product models.Product
+	dest := []interface{}{
+		&product.ID,
+		&product.Name,
+		&product.Slug,
+		&product.Description,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.Image,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Version,
+		jsonColumn{value: &product.Options},
+		&product.Status,
+		&product.PublishAt,
+		&product.BrandID,
+		&product.Type,
+		&product.Rating,
+		&product.ReviewCount,
+		(*categoryIDs)(&product.Categories),
+		jsonColumn{value: &product.Prices},
+		jsonColumn{value: &product.Promotions},
+		jsonColumn{value: &product.Translations},
+		jsonColumn{value: &product.Attributes},
+		jsonColumn{value: &product.Components},
+		jsonColumn{value: &product.Relations},
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	product.SalePrice = models.SalePrice(product.Promotions, time.Now().UTC())
+
+	return &product, nil
+}
+
+func (r *productRepository) GetAll(ctx context.Context, filter *models.ProductFilter, page int, size int) ([]*models.Product, error) {
 	rows, err := r.database.QueryContext(ctx,
-		`SELECT 
-																					id, 
-																					name,
-																					slug, 
-																					description, 
-																					price,
-																					image,
-																					created_at, 
-																					COALESCE(updated_at, '1900-01-01 00:00') updated_at, 
-																					version 
-																				FROM products 
-																				WHERE name ILIKE '%' || $1 || '%'
-																				AND deleted = false
-																				ORDER BY name ASC
-																				LIMIT $2 OFFSET $3`, name, size, (page-1)*size)
+		`SELECT `+productColumns+`
+		FROM products 
+		WHERE (
+			name ILIKE '%' || $1 || '%'
+			OR EXISTS (
+				SELECT 1
+				FROM product_translations
+				WHERE product_id = products.id
+				AND locale = $7
+				AND product_translations.name ILIKE '%' || $1 || '%'
+			)
+		)
+		AND deleted = false
+		AND (
+			COALESCE(cardinality($4::uuid[]), 0) = 0
+			OR EXISTS (
+				SELECT 1 
+				FROM product_categories 
+				WHERE product_id = products.id 
+				AND category_id = ANY($4::uuid[])
+			)
+		)
+		AND (
+			$5 = ''
+			OR EXISTS (
+				SELECT 1
+				FROM product_variants
+				WHERE productid = products.id
+				AND sku = $5
+				AND deleted = false
+			)
+		)
+		AND ($6 = '' OR status = $6)
+		AND ($9::uuid IS NULL OR brand_id = $9)
+		AND (COALESCE(cardinality($10::uuid[]), 0) = 0 OR id = ANY($10::uuid[]))
+		AND NOT EXISTS (
+			SELECT 1
+			FROM jsonb_to_recordset($8::jsonb) AS filters(code text, value text, min numeric, max numeric)
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM product_attributes
+				INNER JOIN attributes ON attributes.id = product_attributes.attribute_id
+				WHERE product_attributes.product_id = products.id
+				AND attributes.code = filters.code
+				AND (filters.value IS NULL OR product_attributes.value #>> '{}' = filters.value)
+				AND (filters.min IS NULL OR (jsonb_typeof(product_attributes.value) = 'number' AND (product_attributes.value #>> '{}')::numeric >= filters.min))
+				AND (filters.max IS NULL OR (jsonb_typeof(product_attributes.value) = 'number' AND (product_attributes.value #>> '{}')::numeric <= filters.max))
+			)
+		)
+		AND rating >= $11
+		AND (
+			($13::uuid IS NULL AND $14 = '')
+			OR EXISTS (
+				SELECT 1
+				FROM product_prices
+				WHERE product_id = products.id
+				AND price_list_id = $13::uuid
+			)
+			OR ($14 <> '' AND currency = $14)
+		)
+		ORDER BY
+			CASE WHEN $12 = 'rating' THEN rating END ASC,
+			CASE WHEN $12 = '-rating' THEN rating END DESC,
+			name ASC
+		LIMIT $2 OFFSET $3`, strings.TrimSpace(filter.Name), size, (page-1)*size, uuidArray(filter.CategoryIDs), strings.TrimSpace(filter.SKU), filter.Status, filter.Locale, jsonColumn{value: filter.Attributes, empty: "[]"}, filter.BrandID, uuidArray(filter.IDs), filter.MinRating, filter.Sort, filter.PriceListID, filter.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -43,22 +262,12 @@ func (r *productRepository) GetAll(ctx context.Context, name string, page int, s
 
 	var products []*models.Product
 	for rows.Next() {
-		var product models.Product
-		err = rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Slug,
-			&product.Description,
-			&product.Price,
-			&product.Image,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-			&product.Version)
+		product, err := r.scanProduct(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		products = append(products, &product)
+		products = append(products, product)
 	}
 	err = rows.Err()
 	if err != nil {
@@ -69,39 +278,26 @@ func (r *productRepository) GetAll(ctx context.Context, name string, page int, s
 }
 
 func (r *productRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Product, error) {
-	var product models.Product
 	row := r.database.QueryRowContext(
 		ctx,
-		`SELECT 
-		id, 
-		name, 
-		slug, 
-		description, 
-		price,
-		image,
-		created_at, 
-		COALESCE(updated_at, '1900-01-01 00:00') updated_at, 
-		version
-		FROM products WHERE id = $1`,
+		`SELECT `+productColumns+`
+		FROM products WHERE id = $1 AND deleted = false`,
 		ID,
 	)
-	if err := row.Scan(
-		&product.ID,
-		&product.Name,
-		&product.Slug,
-		&product.Description,
-		&product.Price,
-		&product.Image,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-		&product.Version); err != nil {
+	product, err := r.scanProduct(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return &product, nil
+	product.Variants, err = r.findVariants(ctx, product.ID, product.Price.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
 
 	// err := row.Scan(
 	// 	&product.ID,
@@ -123,82 +319,213 @@ func (r *productRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models
 }
 
 func (r *productRepository) FindBySlug(ctx context.Context, slug string) (*models.Product, error) {
-	var product models.Product
+	var quantity uint
 	row := r.database.QueryRowContext(
 		ctx,
-		`SELECT 
-		id, 
-		name, 
-		slug, 
-		description, 
-		price,
-		image,
-		created_at, 
-		COALESCE(updated_at, '1900-01-01 00:00') updated_at, 
-		version,
-		(	SELECT COUNT(productid) 
-			FROM stores 
-			WHERE productid = products.id 
-			AND stores.deleted = false 
-			AND sold = false
-			AND booked_at <= NOW()::timestamptz
-			) as quantity
-		FROM products WHERE slug = $1`,
+		`SELECT `+productColumns+`,
+		`+productAvailableColumn+` as quantity
+		FROM products 
+		WHERE (
+			slug = $1
+			OR EXISTS (
+				SELECT 1
+				FROM product_translations
+				WHERE product_id = products.id
+				AND slug = $1
+			)
+		)
+		AND deleted = false
+		ORDER BY (slug = $1) DESC, id
+		LIMIT 1`,
 		slug,
 	)
-	if err := row.Scan(
-		&product.ID,
-		&product.Name,
-		&product.Slug,
-		&product.Description,
-		&product.Price,
-		&product.Image,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-		&product.Version,
-		&product.Quantity); err != nil {
+	product, err := r.scanProduct(row, &quantity)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
+	product.Quantity = quantity
 
-	return &product, nil
+	product.Variants, err = r.findVariants(ctx, product.ID, product.Price.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *productRepository) Export(ctx context.Context, deleted bool, fn func(product *models.Product) error) error {
+	rows, err := r.database.QueryContext(ctx,
+		`SELECT `+productColumns+`,
+		`+productAvailableColumn+` as quantity,
+		deleted,
+		`+productExportVariantsColumn+` as variants
+		FROM products
+		WHERE ($1 OR deleted = false)
+		ORDER BY name ASC`, deleted)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quantity uint
+		var deleted bool
+		var variants []*exportVariant
+		product, err := r.scanProduct(rows, &quantity, &deleted, jsonColumn{value: &variants})
+		if err != nil {
+			return err
+		}
+		product.Quantity = quantity
+		product.Deleted = deleted
+
+		for _, variant := range variants {
+			product.Variants = append(product.Variants, variant.toModel(product.Price.Currency))
+		}
+
+		err = fn(product)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+const productExportVariantsColumn = `(
+			SELECT json_agg(json_build_object(
+				'id', product_variants.id,
+				'productid', product_variants.productid,
+				'sku', product_variants.sku,
+				'options', product_variants.options,
+				'price', product_variants.price,
+				'created_at', product_variants.created_at,
+				'updated_at', COALESCE(product_variants.updated_at, '1900-01-01 00:00'),
+				'version', product_variants.version,
+				'quantity', (
+					SELECT COUNT(id)
+					FROM stores
+					WHERE variantid = product_variants.id
+					AND stores.deleted = false
+					AND sold = false
+					AND booked_at <= NOW()::timestamptz
+				)
+			) ORDER BY product_variants.sku ASC)
+			FROM product_variants
+			WHERE product_variants.productid = products.id
+			AND product_variants.deleted = false
+		)`
+
+type exportVariant struct {
+	ID        uuid.UUID           `json:"id"`
+	ProductID uuid.UUID           `json:"productid"`
+	SKU       string              `json:"sku"`
+	Options   map[string]string   `json:"options"`
+	Price     decimal.NullDecimal `json:"price"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Version   uint                `json:"version"`
+	Quantity  uint                `json:"quantity"`
+}
+
+func (v *exportVariant) toModel(currency string) *models.ProductVariant {
+	variant := &models.ProductVariant{
+		ID:        v.ID,
+		ProductID: v.ProductID,
+		SKU:       v.SKU,
+		Options:   v.Options,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+		Version:   v.Version,
+		Quantity:  v.Quantity,
+	}
+
+	if v.Price.Valid {
+		price := models.NewMoney(v.Price.Decimal, currency)
+		variant.Price = &price
+	}
+
+	return variant
 }
 
 func (r *productRepository) FindByName(ctx context.Context, name string) (*models.Product, error) {
-	var product models.Product
-	row := r.database.QueryRowContext(ctx, "SELECT id, name, slug, description, price, image, created_at, COALESCE(updated_at, '1900-01-01 00:00') updated_at, version FROM products WHERE name = $1", name)
-	if err := row.Scan(
-		&product.ID,
-		&product.Name,
-		&product.Slug,
-		&product.Description,
-		&product.Price,
-		&product.Image,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-		&product.Version); err != nil {
+	row := r.database.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE name = $1 AND deleted = false", name)
+	product, err := r.scanProduct(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return &product, nil
+	return product, nil
 }
 
 func (r *productRepository) Create(ctx context.Context, product *models.Product) (*models.Product, error) {
-	sql := "INSERT INTO products (id, name, slug, description, price, image, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	sql := "INSERT INTO products (id, name, slug, description, price, currency, image, options, status, publish_at, created_at, brand_id, type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)"
+
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	_, err := r.database.ExecContext(ctx, sql,
+	_, err = tx.ExecContext(ctx, sql,
 		product.ID,
 		product.Name,
 		product.Slug,
 		product.Description,
-		product.Price,
+		product.Price.Amount,
+		product.Price.Currency,
 		product.Image,
-		product.CreatedAt)
+		jsonColumn{value: product.Options, empty: "[]"},
+		product.Status,
+		r.publishAt(product),
+		product.CreatedAt,
+		product.BrandID,
+		product.Type)
+	if err != nil {
+		return nil, slugConflict(err, product.Slug)
+	}
+
+	err = r.setCategories(ctx, tx, product.ID, product.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setVariants(ctx, tx, product.ID, product.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setPrices(ctx, tx, product.ID, product.Prices)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setPromotions(ctx, tx, product.ID, product.Promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setTranslations(ctx, tx, product.ID, product.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setAttributes(ctx, tx, product.ID, product.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setComponents(ctx, tx, product.ID, product.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -207,19 +534,83 @@ func (r *productRepository) Create(ctx context.Context, product *models.Product)
 }
 
 func (r *productRepository) Update(ctx context.Context, product *models.Product) (*models.Product, error) {
-	sql := "UPDATE products SET name = $1, slug = $2, description = $3, price = $4, image = $5, updated_at = $6, version = $7 WHERE id = $8 and version = ($7-1)"
+	sql := "UPDATE products SET name = $1, slug = $2, description = $3, price = $4, image = $5, updated_at = $6, version = $7, options = $9, currency = $10, status = $11, publish_at = $12, brand_id = $13 WHERE id = $8 and version = ($7-1)"
+
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = r.setSlugHistory(ctx, tx, product.ID, product.Slug)
+	if err != nil {
+		return nil, err
+	}
 
 	product.Version++
 	product.UpdatedAt = time.Now().UTC()
-	_, err := r.database.ExecContext(ctx, sql,
+	result, err := tx.ExecContext(ctx, sql,
 		product.Name,
 		product.Slug,
 		product.Description,
-		product.Price,
+		product.Price.Amount,
 		product.Image,
 		product.UpdatedAt,
 		product.Version,
-		product.ID)
+		product.ID,
+		jsonColumn{value: product.Options, empty: "[]"},
+		product.Price.Currency,
+		product.Status,
+		r.publishAt(product),
+		product.BrandID)
+	if err != nil {
+		return nil, slugConflict(err, product.Slug)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, models.NewConflictError("product has been changed by another request")
+	}
+
+	err = r.setCategories(ctx, tx, product.ID, product.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setVariants(ctx, tx, product.ID, product.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setPrices(ctx, tx, product.ID, product.Prices)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setPromotions(ctx, tx, product.ID, product.Promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setTranslations(ctx, tx, product.ID, product.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setAttributes(ctx, tx, product.ID, product.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.setComponents(ctx, tx, product.ID, product.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -227,11 +618,594 @@ func (r *productRepository) Update(ctx context.Context, product *models.Product)
 	return product, nil
 }
 
-func (r *productRepository) Delete(ctx context.Context, ID uuid.UUID) error {
-	_, err := r.database.ExecContext(ctx, "UPDATE products SET deleted = true WHERE id = $1", ID)
+func (r *productRepository) GetScheduled(ctx context.Context, until time.Time) ([]*models.Product, error) {
+	rows, err := r.database.QueryContext(ctx,
+		`SELECT `+productColumns+`
+		FROM products 
+		WHERE status = $1
+		AND publish_at <= $2
+		AND deleted = false
+		ORDER BY publish_at ASC`, models.ProductScheduled, until)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nil
+	var products []*models.Product
+	for rows.Next() {
+		product, err := r.scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productRepository) UpdateStatus(ctx context.Context, product *models.Product, previousStatus string) (*models.Product, error) {
+	sql := "UPDATE products SET status = $1, publish_at = $2, updated_at = $3, version = $4 WHERE id = $5 AND version = ($4-1) AND status = $6"
+
+	product.Version++
+	product.UpdatedAt = time.Now().UTC()
+	result, err := r.database.ExecContext(ctx, sql,
+		product.Status,
+		r.publishAt(product),
+		product.UpdatedAt,
+		product.Version,
+		product.ID,
+		previousStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, models.NewConflictError("product status has been changed by another request")
+	}
+
+	return product, nil
+}
+
+func (r *productRepository) UpdateRelations(ctx context.Context, product *models.Product) (*models.Product, error) {
+	sql := "UPDATE products SET updated_at = $1, version = $2 WHERE id = $3 AND version = ($2-1) AND deleted = false"
+
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	product.Version++
+	product.UpdatedAt = time.Now().UTC()
+	result, err := tx.ExecContext(ctx, sql,
+		product.UpdatedAt,
+		product.Version,
+		product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, models.NewConflictError("product has been changed by another request")
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_relations WHERE product_id = $1", product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, relation := range product.Relations {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO product_relations (product_id, related_id, type, position) VALUES ($1, $2, $3, $4)",
+			product.ID,
+			relation.ProductID,
+			relation.Type,
+			relation.Position)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *productRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deletedAt := time.Now().UTC()
+	deletionID := uuid.New()
+	_, err = tx.ExecContext(ctx,
+		`UPDATE products SET deleted = true, deleted_at = $2, deletion_id = $3, updated_at = $2, version = version + 1 
+		WHERE id = $1 
+		AND deleted = false`, ID, deletedAt, deletionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE stores SET deleted = true, deletion_id = $3, updated_at = $2, version = version + 1 
+		WHERE productid = $1 
+		AND sold = false 
+		AND deleted = false 
+		AND (booked_at IS NULL OR booked_at <= $2)`, ID, deletedAt, deletionID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *productRepository) Restore(ctx context.Context, ID uuid.UUID) (*models.Product, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deletedAt sql.NullTime
+	var deletionID uuid.NullUUID
+	var conflict bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT deleted_at, deletion_id, EXISTS (
+			SELECT 1 
+			FROM products other 
+			WHERE other.deleted = false 
+			AND other.id <> products.id 
+			AND (other.name = products.name OR other.slug = products.slug)
+		)
+		FROM products 
+		WHERE id = $1 
+		AND deleted = true 
+		FOR UPDATE`, ID).Scan(&deletedAt, &deletionID, &conflict)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if conflict {
+		return nil, models.NewConflictError("product with this name or slug already exists with another id")
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE products SET deleted = false, deleted_at = NULL, deletion_id = NULL, updated_at = NOW(), version = version + 1 
+		WHERE id = $1`, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case deletionID.Valid:
+		_, err = tx.ExecContext(ctx,
+			`UPDATE stores SET deleted = false, deletion_id = NULL, updated_at = NOW(), version = version + 1 
+			WHERE productid = $1 
+			AND deletion_id = $2`, ID, deletionID)
+		if err != nil {
+			return nil, err
+		}
+	case deletedAt.Valid:
+		_, err = tx.ExecContext(ctx,
+			`UPDATE stores SET deleted = false, updated_at = NOW(), version = version + 1 
+			WHERE productid = $1 
+			AND sold = false 
+			AND deleted = true 
+			AND updated_at = $2`, ID, deletedAt.Time)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, ID)
+}
+
+func (r *productRepository) FindCurrentSlug(ctx context.Context, slug string) (string, error) {
+	var current string
+	err := r.database.QueryRowContext(ctx,
+		`SELECT products.slug 
+		FROM product_slug_history 
+		INNER JOIN products ON products.id = product_slug_history.product_id 
+		WHERE product_slug_history.slug = $1 
+		AND products.deleted = false`, slug).Scan(&current)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return current, nil
+}
+
+func (r *productRepository) setSlugHistory(ctx context.Context, tx *sql.Tx, productID uuid.UUID, slug string) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO product_slug_history (slug, product_id) 
+		SELECT slug, id 
+		FROM products 
+		WHERE id = $1 
+		AND slug <> $2 
+		ON CONFLICT (slug) DO UPDATE SET product_id = EXCLUDED.product_id, created_at = NOW()`, productID, slug)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_slug_history WHERE slug = $1", slug)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) setCategories(ctx context.Context, tx *sql.Tx, productID uuid.UUID, categories []uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_categories WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+
+	if len(categories) == 0 {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO product_categories (product_id, category_id) 
+		SELECT $1, UNNEST($2::uuid[]) 
+		ON CONFLICT DO NOTHING`, productID, uuidArray(categories))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) setPrices(ctx context.Context, tx *sql.Tx, productID uuid.UUID, prices []*models.ProductPrice) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_prices WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	priceLists := make([]uuid.UUID, 0, len(prices))
+	amounts := make(pq.StringArray, 0, len(prices))
+	for _, price := range prices {
+		priceLists = append(priceLists, price.PriceListID)
+		amounts = append(amounts, price.Price.Amount.String())
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO product_prices (product_id, price_list_id, price) 
+		SELECT $1, UNNEST($2::uuid[]), UNNEST($3::numeric[])`, productID, uuidArray(priceLists), amounts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) setVariantPrices(ctx context.Context, tx *sql.Tx, productID uuid.UUID, variantID uuid.UUID, prices []*models.ProductPrice) error {
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM product_variant_prices 
+		WHERE variant_id = $1 
+		AND EXISTS (SELECT 1 FROM product_variants WHERE id = $1 AND productid = $2)`, variantID, productID)
+	if err != nil {
+		return err
+	}
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	priceLists := make([]uuid.UUID, 0, len(prices))
+	amounts := make(pq.StringArray, 0, len(prices))
+	for _, price := range prices {
+		priceLists = append(priceLists, price.PriceListID)
+		amounts = append(amounts, price.Price.Amount.String())
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO product_variant_prices (variant_id, price_list_id, price) 
+		SELECT $1, UNNEST($3::uuid[]), UNNEST($4::numeric[])
+		WHERE EXISTS (SELECT 1 FROM product_variants WHERE id = $1 AND productid = $2)`, variantID, productID, uuidArray(priceLists), amounts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) setPromotions(ctx context.Context, tx *sql.Tx, productID uuid.UUID, promotions []*models.ProductPromotion) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_promotions WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+
+	for _, promotion := range promotions {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO product_promotions (id, product_id, price_list_id, price, starts_at, ends_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			promotion.ID,
+			productID,
+			promotion.PriceListID,
+			promotion.Price.Amount,
+			promotion.StartsAt,
+			promotion.EndsAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// slugConflict reports a unique violation on the product slug as a conflict, which happens when a concurrent request
+// takes the slug between the availability check and the write.
+func slugConflict(err error, slug string) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "ux_products_slug" {
+		return models.NewConflictError(fmt.Sprintf("product with the slug %s already exists with another id", slug))
+	}
+
+	return err
+}
+
+func (r *productRepository) setTranslations(ctx context.Context, tx *sql.Tx, productID uuid.UUID, translations []*models.ProductTranslation) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_translations WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+
+	for _, translation := range translations {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO product_translations (product_id, locale, name, slug, description) VALUES ($1, $2, $3, $4, $5)",
+			productID,
+			translation.Locale,
+			translation.Name,
+			translation.Slug,
+			translation.Description)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "ux_product_translations_slug" {
+			return models.NewConflictError(fmt.Sprintf("product with the %s slug %s already exists with another id", translation.Locale, translation.Slug))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productRepository) setAttributes(ctx context.Context, tx *sql.Tx, productID uuid.UUID, attributes []*models.ProductAttribute) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_attributes WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range attributes {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO product_attributes (product_id, attribute_id, value) VALUES ($1, $2, $3)",
+			productID,
+			attribute.AttributeID,
+			jsonColumn{value: attribute.Value})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productRepository) setComponents(ctx context.Context, tx *sql.Tx, bundleID uuid.UUID, components []*models.ProductComponent) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_components WHERE bundle_id = $1", bundleID)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range components {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO product_components (bundle_id, product_id, variant_id, quantity) VALUES ($1, $2, $3, $4)",
+			bundleID,
+			component.ProductID,
+			component.VariantID,
+			component.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productRepository) FindComponents(ctx context.Context, bundleID uuid.UUID) ([]*models.ProductComponent, error) {
+	rows, err := r.database.QueryContext(ctx,
+		`SELECT 
+		product_components.product_id,
+		product_components.variant_id,
+		product_components.quantity
+		FROM product_components
+		INNER JOIN products ON products.id = product_components.bundle_id
+		WHERE product_components.bundle_id = $1
+		AND products.type = $2
+		AND products.status = $3
+		AND products.deleted = false
+		ORDER BY product_components.product_id`, bundleID, models.ProductBundle, models.ProductPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var components []*models.ProductComponent
+	for rows.Next() {
+		var component models.ProductComponent
+		err = rows.Scan(
+			&component.ProductID,
+			&component.VariantID,
+			&component.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, &component)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func (r *productRepository) findVariants(ctx context.Context, productID uuid.UUID, currency string) ([]*models.ProductVariant, error) {
+	rows, err := r.database.QueryContext(ctx,
+		`SELECT 
+		id,
+		productid,
+		sku,
+		options,
+		price,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version,
+		(	SELECT COUNT(id) 
+			FROM stores 
+			WHERE variantid = product_variants.id 
+			AND stores.deleted = false 
+			AND sold = false
+			AND booked_at <= NOW()::timestamptz
+			) as quantity,
+		`+productVariantPricesColumn+`
+		FROM product_variants 
+		WHERE productid = $1 
+		AND deleted = false
+		ORDER BY sku ASC`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []*models.ProductVariant
+	for rows.Next() {
+		var variant models.ProductVariant
+		var price decimal.NullDecimal
+		err = rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.SKU,
+			jsonColumn{value: &variant.Options},
+			&price,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+			&variant.Version,
+			&variant.Quantity,
+			jsonColumn{value: &variant.Prices})
+		if err != nil {
+			return nil, err
+		}
+
+		if price.Valid {
+			value := models.NewMoney(price.Decimal, currency)
+			variant.Price = &value
+		}
+
+		variants = append(variants, &variant)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (r *productRepository) setVariants(ctx context.Context, tx *sql.Tx, productID uuid.UUID, variants []*models.ProductVariant) error {
+	IDs := []uuid.UUID{}
+	for _, variant := range variants {
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO product_variants (id, productid, sku, options, price, created_at) 
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (id) DO UPDATE SET 
+				sku = EXCLUDED.sku,
+				options = EXCLUDED.options,
+				price = EXCLUDED.price,
+				updated_at = NOW(),
+				version = product_variants.version + 1,
+				deleted = false
+			WHERE product_variants.productid = EXCLUDED.productid`,
+			variant.ID,
+			productID,
+			variant.SKU,
+			jsonColumn{value: variant.Options, empty: "{}"},
+			r.variantPrice(variant),
+			variant.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return models.NewConflictError(fmt.Sprintf("variant %s belongs to another product", variant.ID))
+		}
+
+		err = r.setVariantPrices(ctx, tx, productID, variant.ID, variant.Prices)
+		if err != nil {
+			return err
+		}
+
+		IDs = append(IDs, variant.ID)
+	}
+
+	_, err := tx.ExecContext(ctx,
+		`UPDATE product_variants SET deleted = true, updated_at = NOW() 
+		WHERE productid = $1 
+		AND deleted = false
+		AND NOT (id = ANY($2::uuid[]))`, productID, uuidArray(IDs))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) publishAt(product *models.Product) sql.NullTime {
+	if product.PublishAt.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: product.PublishAt, Valid: true}
+}
+
+func (r *productRepository) variantPrice(variant *models.ProductVariant) decimal.NullDecimal {
+	if variant.Price == nil {
+		return decimal.NullDecimal{}
+	}
+
+	return decimal.NullDecimal{Decimal: variant.Price.Amount, Valid: true}
 }
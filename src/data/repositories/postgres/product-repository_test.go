@@ -0,0 +1,104 @@
+package postgres_repository
+
+import (
+	"context"
+	"errors"
+	"product/src/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func testProduct(name string, variants ...*models.ProductVariant) *models.Product {
+	ID := uuid.New()
+	return &models.Product{
+		ID:        ID,
+		Name:      name,
+		Slug:      name + "-" + ID.String(),
+		Price:     models.NewMoney(decimal.RequireFromString("10.00"), "BRL"),
+		Status:    models.ProductDraft,
+		Type:      models.ProductSimple,
+		Variants:  variants,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func TestProductRepositoryForeignVariant(t *testing.T) {
+	database := testDatabase(t)
+	repository := NewProductRepository(database)
+	ctx := context.Background()
+
+	variantID := uuid.New()
+	owner, err := repository.Create(ctx, testProduct("owner", &models.ProductVariant{
+		ID:        variantID,
+		SKU:       "owner-" + variantID.String(),
+		CreatedAt: time.Now().UTC(),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := repository.Create(ctx, testProduct("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other.Variants = []*models.ProductVariant{{
+		ID:        variantID,
+		SKU:       "other-" + variantID.String(),
+		CreatedAt: time.Now().UTC(),
+	}}
+	_, err = repository.Update(ctx, other)
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Update() error = %v, want a conflict", err)
+	}
+
+	var (
+		productID uuid.UUID
+		sku       string
+	)
+	row := database.QueryRowContext(ctx, `SELECT productid, sku FROM product_variants WHERE id = $1`, variantID)
+	err = row.Scan(&productID, &sku)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if productID != owner.ID || sku != "owner-"+variantID.String() {
+		t.Errorf("variant belongs to %s with sku %s, want %s with sku owner-%s", productID, sku, owner.ID, variantID)
+	}
+}
+
+func TestProductRepositorySlugConflict(t *testing.T) {
+	database := testDatabase(t)
+	repository := NewProductRepository(database)
+	ctx := context.Background()
+
+	first, err := repository.Create(ctx, testProduct("first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := testProduct("second")
+	second.Slug = first.Slug
+	_, err = repository.Create(ctx, second)
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Create() error = %v, want a conflict", err)
+	}
+
+	third, err := repository.Create(ctx, testProduct("third"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	third.Slug = first.Slug
+	_, err = repository.Update(ctx, third)
+	if !errors.As(err, &conflict) {
+		t.Errorf("Update() error = %v, want a conflict", err)
+	}
+}
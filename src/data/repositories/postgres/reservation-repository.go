@@ -0,0 +1,388 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type reservationRepository struct {
+	database *sql.DB
+}
+
+func NewReservationRepository(database *sql.DB) *reservationRepository {
+	return &reservationRepository{
+		database: database,
+	}
+}
+
+const reservationColumns = `
+	id,
+	order_id,
+	COALESCE(customer_id, '') customer_id,
+	lines,
+	status,
+	expires_at,
+	created_at,
+	COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+	version`
+
+func (r *reservationRepository) FindByOrder(ctx context.Context, orderID string) (*models.Reservation, error) {
+	row := r.database.QueryRowContext(ctx, `SELECT `+reservationColumns+`
+																						FROM reservations
+																						WHERE order_id = $1
+																						ORDER BY created_at DESC
+																						LIMIT 1`, orderID)
+	reservation, err := r.scanReservation(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) FindExpired(ctx context.Context, now time.Time) ([]*models.Reservation, error) {
+	rows, err := r.database.QueryContext(ctx, `SELECT `+reservationColumns+`
+																						FROM reservations
+																						WHERE
+																							status = $1
+																							AND expires_at <= $2
+																						ORDER BY expires_at ASC`, models.ReservationActive, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*models.Reservation
+	for rows.Next() {
+		reservation, err := r.scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reservations = append(reservations, reservation)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (r *reservationRepository) Extend(ctx context.Context, ID uuid.UUID, expiresAt time.Time) ([]*models.Store, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	err = r.lockActive(ctx, tx, ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE reservations SET
+																	expires_at = $2,
+																	updated_at = $3,
+																	version = version + 1
+																WHERE id = $1`, ID, expiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `UPDATE stores SET
+																		booked_at = $2,
+																		updated_at = $3,
+																		version = version + 1
+																	WHERE
+																		reservation_id = $1
+																		AND deleted = false
+																		AND sold = false
+																	RETURNING
+																		id,
+																		productid,
+																		variantid,
+																		reservation_id,
+																		booked_at,
+																		sold,
+																		created_at,
+																		updated_at,
+																		version`, ID, expiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []*models.Store
+	for rows.Next() {
+		var store models.Store
+		err = rows.Scan(
+			&store.ID,
+			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
+			&store.BookedAt,
+			&store.Sold,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+			&store.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, &store)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+func (r *reservationRepository) Pay(ctx context.Context, ID uuid.UUID, storeIDs []uuid.UUID) ([]*models.Store, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	err = r.lockActive(ctx, tx, ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter interface{}
+	if len(storeIDs) > 0 {
+		filter = uuidArray(storeIDs)
+	}
+
+	rows, err := tx.QueryContext(ctx, `UPDATE stores SET
+																		booked_at = $3,
+																		sold = true,
+																		updated_at = $4,
+																		version = version + 1
+																	WHERE
+																		reservation_id = $1
+																		AND deleted = false
+																		AND sold = false
+																		AND ($2::uuid[] IS NULL OR id = ANY($2::uuid[]))
+																	RETURNING
+																		id,
+																		productid,
+																		variantid,
+																		reservation_id,
+																		booked_at,
+																		sold,
+																		created_at,
+																		updated_at,
+																		version`, ID, filter, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []*models.Store
+	paid := map[uuid.UUID]bool{}
+	for rows.Next() {
+		var store models.Store
+		err = rows.Scan(
+			&store.ID,
+			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
+			&store.BookedAt,
+			&store.Sold,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+			&store.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, &store)
+		paid[store.ID] = true
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storeID := range storeIDs {
+		if !paid[storeID] {
+			return nil, fmt.Errorf("store id: %v is not reserved by this order", storeID)
+		}
+	}
+
+	if len(stores) == 0 {
+		return nil, fmt.Errorf("reservation %v has no stores to pay", ID)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE reservations SET
+																	status = CASE
+																		WHEN EXISTS (
+																			SELECT 1
+																			FROM stores
+																			WHERE
+																				reservation_id = $1
+																				AND deleted = false
+																				AND sold = false
+																		) THEN status
+																		ELSE $2
+																	END,
+																	updated_at = $3,
+																	version = version + 1
+																WHERE id = $1`, ID, models.ReservationPaid, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+func (r *reservationRepository) Release(ctx context.Context, ID uuid.UUID, status string) ([]*models.Store, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, `UPDATE reservations SET
+																				status = $2,
+																				updated_at = $3,
+																				version = version + 1
+																			WHERE
+																				id = $1
+																				AND status = $4`, ID, status, now, models.ReservationActive)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, models.NewConflictError(fmt.Sprintf("reservation %v is not active", ID))
+	}
+
+	rows, err := tx.QueryContext(ctx, `UPDATE stores SET
+																		booked_at = $2,
+																		reservation_id = NULL,
+																		updated_at = $3,
+																		version = version + 1
+																	WHERE
+																		reservation_id = $1
+																		AND deleted = false
+																		AND sold = false
+																	RETURNING
+																		id,
+																		productid,
+																		variantid,
+																		reservation_id,
+																		booked_at,
+																		sold,
+																		created_at,
+																		updated_at,
+																		version`, ID, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []*models.Store
+	for rows.Next() {
+		var store models.Store
+		err = rows.Scan(
+			&store.ID,
+			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
+			&store.BookedAt,
+			&store.Sold,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+			&store.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, &store)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+func (r *reservationRepository) scanReservation(row productScanner) (*models.Reservation, error) {
+	var reservation models.Reservation
+	err := row.Scan(
+		&reservation.ID,
+		&reservation.OrderID,
+		&reservation.CustomerID,
+		jsonColumn{value: &reservation.Lines},
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+		&reservation.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (r *reservationRepository) lockActive(ctx context.Context, tx *sql.Tx, ID uuid.UUID, now time.Time) error {
+	var (
+		status    string
+		expiresAt time.Time
+	)
+
+	row := tx.QueryRowContext(ctx, `SELECT status, expires_at FROM reservations WHERE id = $1 FOR UPDATE`, ID)
+	if err := row.Scan(&status, &expiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("reservation %v not found", ID)
+		}
+		return err
+	}
+
+	if status != models.ReservationActive {
+		return models.NewConflictError(fmt.Sprintf("reservation %v is %s", ID, status))
+	}
+
+	if !expiresAt.After(now) {
+		return models.NewConflictError(fmt.Sprintf("reservation %v has expired", ID))
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type reviewRepository struct {
+	database *sql.DB
+}
+
+func NewReviewRepository(database *sql.DB) *reviewRepository {
+	return &reviewRepository{
+		database: database,
+	}
+}
+
+const reviewColumns = `
+		id,
+		product_id,
+		user_id,
+		rating,
+		COALESCE(title, '') title,
+		COALESCE(comment, '') comment,
+		status,
+		created_at,
+		COALESCE(updated_at, '1900-01-01 00:00') updated_at,
+		version`
+
+func (r *reviewRepository) scanReview(row productScanner) (*models.Review, error) {
+	var review models.Review
+	err := row.Scan(
+		&review.ID,
+		&review.ProductID,
+		&review.UserID,
+		&review.Rating,
+		&review.Title,
+		&review.Comment,
+		&review.Status,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepository) queryRow(ctx context.Context, query string, args ...interface{}) (*models.Review, error) {
+	review, err := r.scanReview(r.database.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetAll(ctx context.Context, filter *models.ReviewFilter, page int, size int) ([]*models.Review, error) {
+	rows, err := r.database.QueryContext(ctx, `SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE deleted = false
+		AND ($1::uuid IS NULL OR product_id = $1)
+		AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`, filter.ProductID, filter.Status, size, (page-1)*size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*models.Review{}
+	for rows.Next() {
+		review, err := r.scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.Review, error) {
+	return r.queryRow(ctx, `SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE id = $1
+		AND deleted = false`, ID)
+}
+
+func (r *reviewRepository) FindByUser(ctx context.Context, productID uuid.UUID, userID string) (*models.Review, error) {
+	return r.queryRow(ctx, `SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE product_id = $1
+		AND user_id = $2
+		AND deleted = false`, productID, userID)
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *models.Review) (*models.Review, error) {
+	sql := "INSERT INTO reviews (id, product_id, user_id, rating, title, comment, status, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+
+	_, err := r.database.ExecContext(ctx, sql,
+		review.ID,
+		review.ProductID,
+		review.UserID,
+		review.Rating,
+		review.Title,
+		review.Comment,
+		review.Status,
+		review.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) UpdateStatus(ctx context.Context, review *models.Review) (*models.Review, error) {
+	sql := "UPDATE reviews SET status = $1, updated_at = $2, version = $3 WHERE id = $4 AND version = ($3-1) AND deleted = false"
+
+	review.Version++
+	review.UpdatedAt = time.Now().UTC()
+	result, err := r.database.ExecContext(ctx, sql,
+		review.Status,
+		review.UpdatedAt,
+		review.Version,
+		review.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, models.NewConflictError("review has been changed by another request")
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	_, err := r.database.ExecContext(ctx, "UPDATE reviews SET deleted = true WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) UpdateRating(ctx context.Context, productID uuid.UUID) (*models.ProductRating, error) {
+	rating := &models.ProductRating{ProductID: productID}
+	err := r.database.QueryRowContext(ctx,
+		`UPDATE products SET
+			rating = COALESCE((
+				SELECT ROUND(AVG(reviews.rating), 2)
+				FROM reviews
+				WHERE reviews.product_id = products.id
+				AND reviews.status = 'approved'
+				AND reviews.deleted = false
+			), 0),
+			review_count = (
+				SELECT COUNT(reviews.id)
+				FROM reviews
+				WHERE reviews.product_id = products.id
+				AND reviews.status = 'approved'
+				AND reviews.deleted = false
+			)
+		WHERE id = $1
+		RETURNING rating, review_count`, productID).Scan(&rating.Rating, &rating.ReviewCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return rating, nil
+}
@@ -3,6 +3,7 @@ package postgres_repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"product/src/models"
 	"strings"
@@ -25,6 +26,8 @@ func (r *storeRepository) LoadBookedStore(ctx context.Context) ([]*models.Store,
 	rows, err := r.database.QueryContext(ctx, `SELECT 
 																							id,
 																							productid, 
+																							variantid,
+																							reservation_id,
 																							COALESCE(booked_at, '1900-01-01 00:00') booked_at,
 																							sold,
 																							created_at,
@@ -42,10 +45,12 @@ func (r *storeRepository) LoadBookedStore(ctx context.Context) ([]*models.Store,
 
 	var stores []*models.Store
 	for rows.Next() {
-		var store *models.Store
+		var store models.Store
 		err = rows.Scan(
 			&store.ID,
 			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
 			&store.BookedAt,
 			&store.Sold,
 			&store.CreatedAt,
@@ -55,7 +60,7 @@ func (r *storeRepository) LoadBookedStore(ctx context.Context) ([]*models.Store,
 			return nil, err
 		}
 
-		stores = append(stores, store)
+		stores = append(stores, &store)
 	}
 	err = rows.Err()
 	if err != nil {
@@ -70,6 +75,8 @@ func (r *storeRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.S
 	row := r.database.QueryRowContext(ctx, `SELECT
 																						id,
 																						productid, 
+																						variantid,
+																						reservation_id,
 																						COALESCE(booked_at, '1900-01-01 00:00') booked_at,
 																						sold,
 																						created_at,
@@ -82,6 +89,8 @@ func (r *storeRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.S
 	if err := row.Scan(
 		&store.ID,
 		&store.ProductID,
+		&store.VariantID,
+		&store.ReservationID,
 		&store.BookedAt,
 		&store.Sold,
 		&store.CreatedAt,
@@ -96,21 +105,102 @@ func (r *storeRepository) FindByID(ctx context.Context, ID uuid.UUID) (*models.S
 	return &store, nil
 }
 
-func (r *storeRepository) Book(ctx context.Context, productID uuid.UUID, quantity uint) ([]*models.Store, error) {
-	rows, err := r.database.QueryContext(ctx, `SELECT 
-																								id,
-																								productid, 
-																								COALESCE(booked_at, '1900-01-01 00:00') booked_at,
-																								sold,
-																								created_at,
-																								COALESCE(updated_at, '1900-01-01 00:00') updated_at,
-																								version 
-																							FROM stores 
-																							WHERE 
-																								deleted = false 
-																								AND sold = false 
-																								AND productid = $1 
-																								LIMIT $2`, productID.String(), quantity)
+func (r *storeRepository) BookOrder(ctx context.Context, reservation *models.Reservation) ([]*models.Store, []*models.StoreBooking, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO reservations (
+																	id,
+																	order_id,
+																	customer_id,
+																	lines,
+																	status,
+																	expires_at,
+																	created_at,
+																	version) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)`,
+		reservation.ID,
+		reservation.OrderID,
+		reservation.CustomerID,
+		jsonColumn{value: reservation.Lines, empty: "[]"},
+		reservation.Status,
+		reservation.ExpiresAt,
+		reservation.CreatedAt,
+		reservation.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		stores    []*models.Store
+		shortages []*models.StoreBooking
+	)
+
+	reservationID := uuid.NullUUID{UUID: reservation.ID, Valid: true}
+	for _, booking := range reservation.Lines {
+		booked, err := r.book(ctx, tx, booking, reservationID, reservation.ExpiresAt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		booking.Available = uint(len(booked))
+		if booking.Available != booking.Quantity {
+			shortages = append(shortages, booking)
+			continue
+		}
+
+		stores = append(stores, booked...)
+	}
+
+	if len(shortages) > 0 {
+		return nil, shortages, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stores, nil, nil
+}
+
+func (r *storeRepository) book(ctx context.Context, tx *sql.Tx, booking *models.StoreBooking, reservationID uuid.NullUUID, bookedAt time.Time) ([]*models.Store, error) {
+	rows, err := tx.QueryContext(ctx, `UPDATE stores SET 
+																		booked_at = $6, 
+																		reservation_id = $8,
+																		updated_at = $7,
+																		version = version + 1 
+																	WHERE id IN (
+																		SELECT id 
+																		FROM stores 
+																		WHERE 
+																			deleted = false 
+																			AND sold = false 
+																			AND booked_at <= $7
+																			AND productid = $1 
+																			AND ($3::uuid IS NULL OR variantid = $3::uuid)
+																			AND EXISTS (
+																				SELECT 1 
+																				FROM products 
+																				WHERE products.id = COALESCE($4::uuid, stores.productid) 
+																				AND products.status = $5
+																				AND products.deleted = false
+																			)
+																		LIMIT $2
+																		FOR UPDATE SKIP LOCKED
+																	)
+																	RETURNING 
+																		id,
+																		productid, 
+																		variantid,
+																		reservation_id,
+																		booked_at,
+																		sold,
+																		created_at,
+																		updated_at,
+																		version`, booking.ProductID, booking.Quantity, booking.VariantID, booking.BundleID, models.ProductPublished, bookedAt, time.Now().UTC(), reservationID)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +212,8 @@ func (r *storeRepository) Book(ctx context.Context, productID uuid.UUID, quantit
 		err = rows.Scan(
 			&store.ID,
 			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
 			&store.BookedAt,
 			&store.Sold,
 			&store.CreatedAt,
@@ -141,6 +233,118 @@ func (r *storeRepository) Book(ctx context.Context, productID uuid.UUID, quantit
 	return stores, nil
 }
 
+func (r *storeRepository) CountAvailable(ctx context.Context, productID uuid.UUID, variantID uuid.NullUUID) (uint, error) {
+	var quantity uint
+	row := r.database.QueryRowContext(ctx, `SELECT COUNT(id) 
+																					FROM stores 
+																					WHERE 
+																						deleted = false 
+																						AND sold = false 
+																						AND booked_at <= $3
+																						AND productid = $1 
+																						AND ($2::uuid IS NULL OR variantid = $2::uuid)`, productID, variantID, time.Now().UTC())
+	err := row.Scan(&quantity)
+	if err != nil {
+		return 0, err
+	}
+
+	return quantity, nil
+}
+
+// LastStockChange returns when the availability of the products last changed, counting
+// bookings that have since lapsed as a change at the moment they expired.
+func (r *storeRepository) LastStockChange(ctx context.Context, productIDs []uuid.UUID) (time.Time, error) {
+	var changedAt sql.NullTime
+	row := r.database.QueryRowContext(ctx, `SELECT MAX(GREATEST(
+																						created_at,
+																						updated_at,
+																						CASE WHEN booked_at <= $2 THEN booked_at END))
+																					FROM stores 
+																					WHERE productid = ANY($1::uuid[])`, uuidArray(productIDs), time.Now().UTC())
+	err := row.Scan(&changedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return changedAt.Time, nil
+}
+
+func (r *storeRepository) WriteOff(ctx context.Context, productID uuid.UUID, variantID uuid.NullUUID, quantity uint) ([]*models.Store, error) {
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `UPDATE stores SET 
+																		deleted = true, 
+																		updated_at = $4,
+																		version = version + 1 
+																	WHERE id IN (
+																		SELECT id 
+																		FROM stores 
+																		WHERE 
+																			deleted = false 
+																			AND sold = false 
+																			AND booked_at <= $4
+																			AND productid = $1 
+																			AND ($3::uuid IS NULL OR variantid = $3::uuid)
+																		LIMIT $2
+																		FOR UPDATE
+																	)
+																	RETURNING 
+																		id,
+																		productid, 
+																		variantid,
+																		reservation_id,
+																		booked_at,
+																		sold,
+																		created_at,
+																		updated_at,
+																		version,
+																		deleted`, productID, quantity, variantID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []*models.Store
+	for rows.Next() {
+		var store models.Store
+		err = rows.Scan(
+			&store.ID,
+			&store.ProductID,
+			&store.VariantID,
+			&store.ReservationID,
+			&store.BookedAt,
+			&store.Sold,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+			&store.Version,
+			&store.Deleted)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, &store)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stores) != int(quantity) {
+		return nil, errors.New("not enough stores")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
 func (r *storeRepository) Create(ctx context.Context, stores []*models.Store) error {
 	var (
 		params []string
@@ -148,18 +352,20 @@ func (r *storeRepository) Create(ctx context.Context, stores []*models.Store) er
 	)
 
 	for i := 0; i < len(stores); i++ {
-		params = append(params, fmt.Sprintf("($%v,$%v,$%v,$%v,$%v,$%v,$%v)",
-			i*7+1,
-			i*7+2,
-			i*7+3,
-			i*7+4,
-			i*7+5,
-			i*7+6,
-			i*7+7,
+		params = append(params, fmt.Sprintf("($%v,$%v,$%v,$%v,$%v,$%v,$%v,$%v)",
+			i*8+1,
+			i*8+2,
+			i*8+3,
+			i*8+4,
+			i*8+5,
+			i*8+6,
+			i*8+7,
+			i*8+8,
 		))
 		vals = append(vals,
 			stores[i].ID,
 			stores[i].ProductID,
+			stores[i].VariantID,
 			stores[i].BookedAt,
 			stores[i].Sold,
 			stores[i].CreatedAt,
@@ -170,6 +376,7 @@ func (r *storeRepository) Create(ctx context.Context, stores []*models.Store) er
 	statement := fmt.Sprintf(`INSERT INTO stores (
 													id, 
 													productid, 
+													variantid,
 													booked_at,
 													sold,
 													created_at, 
@@ -191,15 +398,17 @@ func (r *storeRepository) Update(ctx context.Context, stores []*models.Store) ([
 	)
 
 	for i := 0; i < len(stores); i++ {
-		params = append(params, fmt.Sprintf("($%v,$%v,$%v,$%v,$%v)",
-			i*5+1,
-			i*5+2,
-			i*5+3,
-			i*5+4,
-			i*5+5,
+		params = append(params, fmt.Sprintf("($%v,$%v,$%v,$%v,$%v,$%v)",
+			i*6+1,
+			i*6+2,
+			i*6+3,
+			i*6+4,
+			i*6+5,
+			i*6+6,
 		))
 		vals = append(vals,
 			stores[i].ID,
+			stores[i].ReservationID,
 			stores[i].BookedAt,
 			stores[i].Sold,
 			stores[i].UpdatedAt,
@@ -207,16 +416,36 @@ func (r *storeRepository) Update(ctx context.Context, stores []*models.Store) ([
 	}
 
 	statement := fmt.Sprintf(`UPDATE stores SET 
+															reservation_id = s.reservation_id::uuid, 
 															booked_at = s.booked_at::timestamp, 
 															sold = s.sold::boolean, 
 															updated_at = s.updated_at::timestamp,
 															version = s.version::integer 
-														FROM (VALUES %s) AS s(id,booked_at,sold,updated_at,version)
+														FROM (VALUES %s) AS s(id,reservation_id,booked_at,sold,updated_at,version)
 														WHERE 
 															stores.id = s.id::uuid
 															AND stores.version = s.version::integer-1`, strings.Join(params, ","))
 
-	_, err := r.database.ExecContext(ctx, statement, vals...)
+	tx, err := r.database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, statement, vals...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows != int64(len(stores)) {
+		return nil, models.NewConflictError("store has been changed by another request")
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
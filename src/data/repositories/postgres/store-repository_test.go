@@ -0,0 +1,193 @@
+package postgres_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"product/src/models"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	bookingWorkers    = 20
+	bookingStock      = 100
+	bookingMaxRetries = 20
+	bookingBackoff    = 5 * time.Millisecond
+)
+
+// testDatabase creates a scratch database on the server in PRODUCT_TEST_POSTGRES_URL,
+// runs the migrations on it and drops it when the test ends.
+func testDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	serverURL := os.Getenv("PRODUCT_TEST_POSTGRES_URL")
+	if len(serverURL) == 0 {
+		t.Skip("PRODUCT_TEST_POSTGRES_URL is not set")
+	}
+
+	server, err := sql.Open("postgres", serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	name := "product_test_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	_, err = server.Exec("CREATE DATABASE " + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	databaseURL, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	databaseURL.Path = "/" + name
+
+	m, err := migrate.New("file://../../../../sql", databaseURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.Up()
+	m.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database, err := sql.Open("postgres", databaseURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		database.Close()
+		_, err := server.Exec("DROP DATABASE IF EXISTS " + name)
+		if err != nil {
+			t.Logf("drop database %s error: %s", name, err)
+		}
+	})
+
+	return database
+}
+
+func TestStoreRepositoryConcurrentBooking(t *testing.T) {
+	database := testDatabase(t)
+	repository := NewStoreRepository(database)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	_, err := database.ExecContext(ctx, `INSERT INTO products (id, name, slug, status) VALUES ($1, $2, $3, $4)`,
+		productID, "booking", "booking-"+productID.String(), models.ProductPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stores := []*models.Store{}
+	for i := 0; i < bookingStock; i++ {
+		stores = append(stores, &models.Store{
+			ID:        uuid.New(),
+			ProductID: productID,
+			CreatedAt: time.Now().UTC(),
+		})
+	}
+
+	err = repository.Create(ctx, stores)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mutex  sync.Mutex
+		wg     sync.WaitGroup
+		errs   []error
+		booked = map[uuid.UUID]int{}
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < bookingWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			retries := 0
+			for {
+				now := time.Now().UTC()
+				claimed, shortages, err := repository.BookOrder(ctx, &models.Reservation{
+					ID:      uuid.New(),
+					OrderID: primitive.NewObjectID().Hex(),
+					Lines: []*models.StoreBooking{
+						{ProductID: productID, Quantity: 1},
+					},
+					Status:    models.ReservationActive,
+					ExpiresAt: now.Add(1 * time.Minute),
+					CreatedAt: now,
+				})
+				if err == nil && len(shortages) > 0 {
+					var available uint
+					available, err = repository.CountAvailable(ctx, productID, uuid.NullUUID{})
+					if err == nil && available > 0 {
+						retries++
+						if retries <= bookingMaxRetries {
+							time.Sleep(time.Duration(retries) * bookingBackoff)
+							continue
+						}
+						err = fmt.Errorf("%d stores still available after %d retries", available, bookingMaxRetries)
+					}
+				}
+
+				mutex.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				}
+				for _, store := range claimed {
+					booked[store.ID]++
+				}
+				mutex.Unlock()
+
+				if err != nil || len(claimed) == 0 {
+					return
+				}
+				retries = 0
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+
+	for ID, count := range booked {
+		if count > 1 {
+			t.Errorf("store %s booked %d times", ID, count)
+		}
+	}
+
+	if len(booked) != bookingStock {
+		t.Errorf("booked %d stores, want %d", len(booked), bookingStock)
+	}
+
+	var reserved int
+	row := database.QueryRowContext(ctx, `SELECT COUNT(id) FROM stores WHERE productid = $1 AND booked_at > $2 AND reservation_id IS NOT NULL`, productID, time.Now().UTC())
+	err = row.Scan(&reserved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reserved != bookingStock {
+		t.Errorf("database has %d booked stores, want %d", reserved, bookingStock)
+	}
+}
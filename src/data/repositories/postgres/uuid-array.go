@@ -0,0 +1,46 @@
+package postgres_repository
+
+import (
+	"database/sql/driver"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const productCategoriesColumn = `ARRAY(
+			SELECT category_id::text 
+			FROM product_categories 
+			WHERE product_id = products.id
+		) categories`
+
+type categoryIDs []uuid.UUID
+
+func (ids *categoryIDs) Scan(src interface{}) error {
+	values := pq.StringArray{}
+	err := values.Scan(src)
+	if err != nil {
+		return err
+	}
+
+	result := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		ID, err := uuid.Parse(value)
+		if err != nil {
+			return err
+		}
+		result = append(result, ID)
+	}
+
+	*ids = result
+
+	return nil
+}
+
+func uuidArray(IDs []uuid.UUID) driver.Valuer {
+	values := make(pq.StringArray, 0, len(IDs))
+	for _, ID := range IDs {
+		values = append(values, ID.String())
+	}
+
+	return values
+}
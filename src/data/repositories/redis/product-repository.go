@@ -2,58 +2,70 @@ package redis_repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"product/src/models"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/RediSearch/redisearch-go/redisearch"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type ProductRepository interface {
-	GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error)
+	GetAll(ctx context.Context, filter *models.ProductFilter, page int, size int) ([]*models.Product, error)
 	Set(ctx context.Context, product *models.Product) (*models.Product, error)
 	Update(ctx context.Context, product *models.Product) (*models.Product, error)
 	Refresh(ctx context.Context, products []*models.Product) error
+	Delete(ctx context.Context, ID uuid.UUID) error
 }
 
 type productRepository struct {
 	database *redis.Client
 }
 
-var search *redisearch.Client
+var searches = map[string]*redisearch.Client{}
 var schema *redisearch.Schema
 
+var attributeFields = map[string]string{}
+var attributeFieldsMutex sync.Mutex
+
 func NewProductRepository(database *redis.Client) *productRepository {
 	result := &productRepository{
 		database: database,
 	}
 
 	addr := database.Options().Addr
-	search = redisearch.NewClient(addr, "productsIndex")
+	for locale := range models.Locales {
+		searches[locale] = redisearch.NewClient(addr, result.indexName(locale))
+	}
 
 	schema = result.schema()
-	search.Drop()
+	for _, search := range searches {
+		search.Drop()
+	}
 
 	return result
 }
 
-func (r *productRepository) GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error) {
+func (r *productRepository) GetAll(ctx context.Context, filter *models.ProductFilter, page int, size int) ([]*models.Product, error) {
 	products := []*models.Product{}
 
-	if len(name) == 1 && len(strings.Trim(name, " ")) == 0 {
-		name = "*"
-	} else {
-		name = fmt.Sprintf("@name:*%s*", name)
+	if !r.attributeFiltersValid(filter.Attributes) {
+		return products, nil
 	}
 
-	docs, _, err := search.Search(redisearch.NewQuery(name).
+	locale := r.locale(filter.Locale)
+	docs, _, err := searches[locale].Search(redisearch.NewQuery(r.query(filter)).
+		SetLanguage(models.Locales[locale]).
 		Limit((page-1)*size, size).
-		SetSortBy("name", true).
-		SetReturnFields("id", "name", "slug", "description", "price", "quantity", "image", "version"))
+		SetSortBy(r.sortBy(filter.Sort)).
+		SetReturnFields("id", "name", "slug", "description", "amount", "currency", "quantity", "image", "categories", "brandid", "options", "variants", "prices", "promotions", "sale_price", "status", "publish_at", "translations", "attributes", "type", "components", "relations", "rating", "review_count", "version"))
 
 	if err != nil {
 		return nil, err
@@ -76,43 +88,48 @@ func (r *productRepository) Set(ctx context.Context, product *models.Product) (*
 		return nil, fmt.Errorf("product is nil")
 	}
 
-	doc := redisearch.NewDocument(product.ID.String(), 1.0)
-	doc.Set("id", product.ID.String()).
-		Set("name", product.Name).
-		Set("slug", product.Slug).
-		Set("description", product.Description).
-		Set("price", product.Price).
-		Set("quantity", product.Quantity).
-		Set("image", product.Image).
-		Set("version", product.Version)
-
-	if err := search.Index(doc); err != nil {
+	if err := r.addAttributeFields(product.Attributes); err != nil {
 		return nil, err
 	}
 
+	for locale, language := range models.Locales {
+		options := redisearch.IndexingOptions{Language: language}
+		if err := searches[locale].IndexOptions(options, r.document(product, locale)); err != nil {
+			return nil, err
+		}
+	}
+
 	return product, nil
 }
 
 func (r *productRepository) Update(ctx context.Context, product *models.Product) (*models.Product, error) {
-	search.DeleteDocument(product.ID.String())
-
-	newdoc := redisearch.NewDocument(product.ID.String(), 1.0)
-	newdoc.Set("id", product.ID).
-		Set("name", product.Name).
-		Set("slug", product.Slug).
-		Set("description", product.Description).
-		Set("price", product.Price).
-		Set("quantity", product.Quantity).
-		Set("image", product.Image).
-		Set("version", product.Version)
-
-	if err := search.Index(newdoc); err != nil {
+	if err := r.addAttributeFields(product.Attributes); err != nil {
 		return nil, err
 	}
 
+	for locale, language := range models.Locales {
+		searches[locale].DeleteDocument(r.documentID(locale, product.ID))
+
+		options := redisearch.IndexingOptions{Language: language}
+		if err := searches[locale].IndexOptions(options, r.document(product, locale)); err != nil {
+			return nil, err
+		}
+	}
+
 	return product, nil
 }
 
+func (r *productRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	for locale, search := range searches {
+		err := search.DeleteDocument(r.documentID(locale, ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *productRepository) Refresh(ctx context.Context, products []*models.Product) error {
 	if len(products) == 0 {
 		return nil
@@ -121,32 +138,312 @@ func (r *productRepository) Refresh(ctx context.Context, products []*models.Prod
 	result := r.database.FlushDB(ctx)
 	fmt.Println(result)
 
-	var docs []redisearch.Document
+	attributeFieldsMutex.Lock()
+	attributeFields = map[string]string{}
 	for _, product := range products {
-		doc := redisearch.NewDocument(product.ID.String(), 1.0)
-		doc.Set("id", product.ID.String()).
-			Set("name", product.Name).
-			Set("slug", product.Slug).
-			Set("description", product.Description).
-			Set("price", product.Price).
-			Set("quantity", product.Quantity).
-			Set("image", product.Image).
-			Set("version", product.Version)
+		for _, attribute := range product.Attributes {
+			attributeFields[r.attributeField(attribute.Code)] = attribute.Type
+		}
+	}
+
+	indexSchema := r.schema()
+	for field, _type := range attributeFields {
+		indexSchema.AddField(r.attributeSchemaField(field, _type))
+	}
+	attributeFieldsMutex.Unlock()
+
+	for locale, language := range models.Locales {
+		var docs []redisearch.Document
+		for _, product := range products {
+			docs = append(docs, r.document(product, locale))
+		}
+
+		definition := redisearch.NewIndexDefinition().
+			AddPrefix(r.documentPrefix(locale)).
+			SetLanguage(language)
+		if err := searches[locale].CreateIndexWithIndexDefinition(indexSchema, definition); err != nil {
+			return err
+		}
+
+		options := redisearch.IndexingOptions{Language: language}
+		if err := searches[locale].IndexOptions(options, docs...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productRepository) locale(locale string) string {
+	if _, ok := models.Locales[locale]; ok {
+		return locale
+	}
+
+	return models.DefaultLocale
+}
+
+func (r *productRepository) indexName(locale string) string {
+	if locale == models.DefaultLocale {
+		return "productsIndex"
+	}
+
+	return fmt.Sprintf("productsIndex:%s", locale)
+}
+
+func (r *productRepository) documentPrefix(locale string) string {
+	return fmt.Sprintf("product:%s:", locale)
+}
+
+func (r *productRepository) documentID(locale string, ID uuid.UUID) string {
+	return r.documentPrefix(locale) + ID.String()
+}
+
+func (r *productRepository) query(filter *models.ProductFilter) string {
+	terms := []string{}
 
-		docs = append(docs, doc)
+	if len(filter.IDs) > 0 {
+		terms = append(terms, fmt.Sprintf("@id:{%s}", r.tags(filter.IDs, "|")))
 	}
 
-	if err := search.CreateIndex(schema); err != nil {
-		return err
+	name := strings.TrimSpace(filter.Name)
+	if len(name) > 0 {
+		terms = append(terms, fmt.Sprintf("@name:*%s*", name))
 	}
 
-	if err := search.Index(docs...); err != nil {
-		return err
+	if len(filter.CategoryIDs) > 0 {
+		terms = append(terms, fmt.Sprintf("@categories:{%s}", r.tags(filter.CategoryIDs, "|")))
+	}
+
+	if filter.BrandID.Valid {
+		terms = append(terms, fmt.Sprintf("@brandid:{%s}", r.escape(filter.BrandID.UUID.String())))
+	}
+
+	sku := strings.TrimSpace(filter.SKU)
+	if len(sku) > 0 {
+		terms = append(terms, fmt.Sprintf("@skus:{%s}", r.escape(sku)))
+	}
+
+	if len(filter.Status) > 0 {
+		terms = append(terms, fmt.Sprintf("@status:{%s}", r.escape(filter.Status)))
+	}
+
+	for _, attribute := range filter.Attributes {
+		terms = append(terms, r.attributeQuery(attribute))
+	}
+
+	if filter.MinRating > 0 {
+		terms = append(terms, fmt.Sprintf("@rating:[%s +inf]", strconv.FormatFloat(filter.MinRating, 'f', -1, 64)))
+	}
+
+	priced := []string{}
+	if filter.PriceListID.Valid {
+		priced = append(priced, fmt.Sprintf("(@pricelists:{%s})", r.escape(filter.PriceListID.UUID.String())))
+	}
+	if len(filter.Currency) > 0 {
+		priced = append(priced, fmt.Sprintf("(@currency:{%s})", r.escape(filter.Currency)))
+	}
+	if len(priced) > 0 {
+		terms = append(terms, "("+strings.Join(priced, "|")+")")
+	}
+
+	if len(terms) == 0 {
+		return "*"
+	}
+
+	return strings.Join(terms, " ")
+}
+
+func (r *productRepository) sortBy(sort string) (string, bool) {
+	switch sort {
+	case models.ProductSortRating:
+		return "rating", true
+	case models.ProductSortRatingDesc:
+		return "rating", false
+	default:
+		return "name", true
+	}
+}
+
+func (r *productRepository) attributeField(code string) string {
+	return "attr_" + code
+}
+
+func (r *productRepository) attributeSchemaField(field string, _type string) redisearch.Field {
+	if _type == models.AttributeNumber {
+		return redisearch.NewNumericFieldOptions(field, redisearch.NumericFieldOptions{})
+	}
+
+	return redisearch.NewTagFieldOptions(field, redisearch.TagFieldOptions{})
+}
+
+func (r *productRepository) addAttributeFields(attributes []*models.ProductAttribute) error {
+	attributeFieldsMutex.Lock()
+	defer attributeFieldsMutex.Unlock()
+
+	for _, attribute := range attributes {
+		field := r.attributeField(attribute.Code)
+		if _, ok := attributeFields[field]; ok {
+			continue
+		}
+
+		for _, search := range searches {
+			if err := search.AddField(r.attributeSchemaField(field, attribute.Type)); err != nil {
+				return err
+			}
+		}
+		attributeFields[field] = attribute.Type
 	}
 
 	return nil
 }
 
+func (r *productRepository) attributeFiltersValid(filters []*models.AttributeFilter) bool {
+	attributeFieldsMutex.Lock()
+	defer attributeFieldsMutex.Unlock()
+
+	for _, filter := range filters {
+		_type, ok := attributeFields[r.attributeField(filter.Code)]
+		if !ok {
+			return false
+		}
+
+		if filter.Range() && _type != models.AttributeNumber {
+			return false
+		}
+
+		if !filter.Range() && _type == models.AttributeNumber {
+			if _, err := strconv.ParseFloat(filter.Value, 64); err != nil {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (r *productRepository) attributeQuery(filter *models.AttributeFilter) string {
+	field := r.attributeField(filter.Code)
+
+	attributeFieldsMutex.Lock()
+	_type := attributeFields[field]
+	attributeFieldsMutex.Unlock()
+
+	if filter.Range() {
+		min, max := "-inf", "+inf"
+		if filter.Min != nil {
+			min = strconv.FormatFloat(*filter.Min, 'f', -1, 64)
+		}
+		if filter.Max != nil {
+			max = strconv.FormatFloat(*filter.Max, 'f', -1, 64)
+		}
+
+		return fmt.Sprintf("@%s:[%s %s]", field, min, max)
+	}
+
+	if _type == models.AttributeNumber {
+		return fmt.Sprintf("@%s:[%s %s]", field, filter.Value, filter.Value)
+	}
+
+	return fmt.Sprintf("@%s:{%s}", field, r.escape(filter.Value))
+}
+
+func (r *productRepository) tags(IDs []uuid.UUID, separator string) string {
+	values := make([]string, 0, len(IDs))
+	for _, ID := range IDs {
+		values = append(values, r.escape(ID.String()))
+	}
+
+	return strings.Join(values, separator)
+}
+
+func (r *productRepository) escape(value string) string {
+	replacer := strings.NewReplacer(
+		"-", "\\-", ".", "\\.", ",", "\\,", " ", "\\ ", "/", "\\/", "(", "\\(", ")", "\\)",
+		"{", "\\{", "}", "\\}", "|", "\\|", "@", "\\@", ":", "\\:", "*", "\\*")
+
+	return replacer.Replace(value)
+}
+
+func (r *productRepository) document(product *models.Product, locale string) redisearch.Document {
+	name, slug, description := product.Name, product.Slug, product.Description
+	translation := models.Translation(product.Translations, locale)
+	if translation != nil {
+		name, slug, description = translation.Name, translation.Slug, translation.Description
+	}
+
+	categories := make([]string, 0, len(product.Categories))
+	for _, category := range product.Categories {
+		categories = append(categories, category.String())
+	}
+
+	brandID := ""
+	if product.BrandID.Valid {
+		brandID = product.BrandID.UUID.String()
+	}
+
+	priceLists := make([]string, 0, len(product.Prices))
+	for _, price := range product.Prices {
+		priceLists = append(priceLists, price.PriceListID.String())
+	}
+
+	skus := make([]string, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		skus = append(skus, variant.SKU)
+	}
+
+	options, _ := json.Marshal(product.Options)
+	variants, _ := json.Marshal(product.Variants)
+	prices, _ := json.Marshal(product.Prices)
+	promotions, _ := json.Marshal(product.Promotions)
+	salePrice, _ := json.Marshal(product.SalePrice)
+
+	translations, _ := json.Marshal(product.Translations)
+	attributes, _ := json.Marshal(product.Attributes)
+	components, _ := json.Marshal(product.Components)
+	relations, _ := json.Marshal(product.Relations)
+
+	doc := redisearch.NewDocument(r.documentID(locale, product.ID), 1.0)
+	doc.Set("id", product.ID.String()).
+		Set("name", name).
+		Set("slug", slug).
+		Set("description", description).
+		Set("price", product.Price.Amount.InexactFloat64()).
+		Set("amount", product.Price.Amount.String()).
+		Set("currency", product.Price.Currency).
+		Set("quantity", product.Quantity).
+		Set("image", product.Image).
+		Set("categories", strings.Join(categories, ",")).
+		Set("brandid", brandID).
+		Set("options", string(options)).
+		Set("variants", string(variants)).
+		Set("prices", string(prices)).
+		Set("pricelists", strings.Join(priceLists, ",")).
+		Set("promotions", string(promotions)).
+		Set("sale_price", string(salePrice)).
+		Set("skus", strings.Join(skus, ",")).
+		Set("status", product.Status).
+		Set("publish_at", product.PublishAt.Format(time.RFC3339)).
+		Set("translations", string(translations)).
+		Set("attributes", string(attributes)).
+		Set("type", product.Type).
+		Set("components", string(components)).
+		Set("relations", string(relations)).
+		Set("rating", product.Rating).
+		Set("review_count", product.ReviewCount).
+		Set("version", product.Version)
+
+	for _, attribute := range product.Attributes {
+		switch value := attribute.Value.(type) {
+		case bool:
+			doc.Set(r.attributeField(attribute.Code), strconv.FormatBool(value))
+		default:
+			doc.Set(r.attributeField(attribute.Code), value)
+		}
+	}
+
+	return doc
+}
+
 func (r *productRepository) schema() *redisearch.Schema {
 	schema := redisearch.NewSchema(redisearch.DefaultOptions).
 		// AddField(redisearch.NewTagFieldOptions("id", redisearch.TagFieldOptions{Separator: byte(';')})).
@@ -155,8 +452,28 @@ func (r *productRepository) schema() *redisearch.Schema {
 		AddField(redisearch.NewTextFieldOptions("slug", redisearch.TextFieldOptions{})).
 		AddField(redisearch.NewTextFieldOptions("description", redisearch.TextFieldOptions{})).
 		AddField(redisearch.NewNumericFieldOptions("price", redisearch.NumericFieldOptions{})).
+		AddField(redisearch.NewTextFieldOptions("amount", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTagFieldOptions("currency", redisearch.TagFieldOptions{})).
 		AddField(redisearch.NewNumericFieldOptions("quantity", redisearch.NumericFieldOptions{})).
 		AddField(redisearch.NewTextFieldOptions("image", redisearch.TextFieldOptions{})).
+		AddField(redisearch.NewTagFieldOptions("categories", redisearch.TagFieldOptions{Separator: byte(',')})).
+		AddField(redisearch.NewTagFieldOptions("brandid", redisearch.TagFieldOptions{})).
+		AddField(redisearch.NewTextFieldOptions("options", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("variants", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("prices", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTagFieldOptions("pricelists", redisearch.TagFieldOptions{Separator: byte(',')})).
+		AddField(redisearch.NewTextFieldOptions("promotions", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("sale_price", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTagFieldOptions("skus", redisearch.TagFieldOptions{Separator: byte(',')})).
+		AddField(redisearch.NewTagFieldOptions("status", redisearch.TagFieldOptions{})).
+		AddField(redisearch.NewTextFieldOptions("publish_at", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("translations", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("attributes", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTagFieldOptions("type", redisearch.TagFieldOptions{})).
+		AddField(redisearch.NewTextFieldOptions("components", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewTextFieldOptions("relations", redisearch.TextFieldOptions{NoIndex: true})).
+		AddField(redisearch.NewNumericFieldOptions("rating", redisearch.NumericFieldOptions{Sortable: true})).
+		AddField(redisearch.NewNumericFieldOptions("review_count", redisearch.NumericFieldOptions{})).
 		AddField(redisearch.NewNumericFieldOptions("version", redisearch.NumericFieldOptions{}))
 
 	return schema
@@ -183,10 +500,18 @@ func (r *productRepository) mapProduct(object *redisearch.Document) (*models.Pro
 		product.Description = description.(string)
 	}
 
-	price := object.Properties["price"]
-	if price != nil {
-		value, _ := strconv.ParseFloat(price.(string), 32)
-		product.Price = float32(value)
+	amount := object.Properties["amount"]
+	if amount != nil {
+		value, err := decimal.NewFromString(amount.(string))
+		if err != nil {
+			return nil, err
+		}
+		product.Price.Amount = value
+	}
+
+	currency := object.Properties["currency"]
+	if currency != nil {
+		product.Price.Currency = currency.(string)
 	}
 
 	quantity := object.Properties["quantity"]
@@ -200,6 +525,128 @@ func (r *productRepository) mapProduct(object *redisearch.Document) (*models.Pro
 		product.Image = image.(string)
 	}
 
+	categories := object.Properties["categories"]
+	if categories != nil && len(categories.(string)) > 0 {
+		for _, value := range strings.Split(categories.(string), ",") {
+			categoryID, err := uuid.Parse(value)
+			if err != nil {
+				return nil, err
+			}
+			product.Categories = append(product.Categories, categoryID)
+		}
+	}
+
+	brandID := object.Properties["brandid"]
+	if brandID != nil && len(brandID.(string)) > 0 {
+		value, err := uuid.Parse(brandID.(string))
+		if err != nil {
+			return nil, err
+		}
+		product.BrandID = uuid.NullUUID{UUID: value, Valid: true}
+	}
+
+	options := object.Properties["options"]
+	if options != nil {
+		err = json.Unmarshal([]byte(options.(string)), &product.Options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variants := object.Properties["variants"]
+	if variants != nil {
+		err = json.Unmarshal([]byte(variants.(string)), &product.Variants)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prices := object.Properties["prices"]
+	if prices != nil {
+		err = json.Unmarshal([]byte(prices.(string)), &product.Prices)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	promotions := object.Properties["promotions"]
+	if promotions != nil {
+		err = json.Unmarshal([]byte(promotions.(string)), &product.Promotions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	salePrice := object.Properties["sale_price"]
+	if salePrice != nil {
+		err = json.Unmarshal([]byte(salePrice.(string)), &product.SalePrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	translations := object.Properties["translations"]
+	if translations != nil {
+		err = json.Unmarshal([]byte(translations.(string)), &product.Translations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attributes := object.Properties["attributes"]
+	if attributes != nil {
+		err = json.Unmarshal([]byte(attributes.(string)), &product.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	components := object.Properties["components"]
+	if components != nil {
+		err = json.Unmarshal([]byte(components.(string)), &product.Components)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	relations := object.Properties["relations"]
+	if relations != nil {
+		err = json.Unmarshal([]byte(relations.(string)), &product.Relations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_type := object.Properties["type"]
+	if _type != nil {
+		product.Type = _type.(string)
+	}
+
+	status := object.Properties["status"]
+	if status != nil {
+		product.Status = status.(string)
+	}
+
+	publishAt := object.Properties["publish_at"]
+	if publishAt != nil {
+		product.PublishAt, err = time.Parse(time.RFC3339, publishAt.(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rating := object.Properties["rating"]
+	if rating != nil {
+		value, _ := strconv.ParseFloat(rating.(string), 64)
+		product.Rating = value
+	}
+
+	reviewCount := object.Properties["review_count"]
+	if reviewCount != nil {
+		value, _ := strconv.ParseUint(reviewCount.(string), 10, 32)
+		product.ReviewCount = uint(value)
+	}
+
 	version := object.Properties["version"]
 	if version != nil {
 		value, _ := strconv.ParseUint(version.(string), 10, 32)
@@ -8,6 +8,7 @@ import (
 	redis_repository "product/src/data/repositories/redis"
 	"product/src/models"
 	"product/src/nats/subjects"
+	"strings"
 
 	command_product "product/src/application/commands/product"
 
@@ -17,15 +18,18 @@ import (
 )
 
 type ProductRepositoryDecorator interface {
-	GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error)
+	GetAll(ctx context.Context, filter *models.ProductFilter, page int, size int) ([]*models.Product, error)
 	FindByID(ctx context.Context, ID uuid.UUID) (*models.Product, error)
 	FindBySlug(ctx context.Context, slug string) (*models.Product, error)
+	GetRelated(ctx context.Context, product *models.Product, relationType string, status string) ([]*models.Product, error)
 }
 
 type productRepositoryDecorator struct {
 	mongoRepository    product_repository.ProductRepository
 	postgresRepository product_repository.ProductRepository
 	redisRepository    redis_repository.ProductRepository
+	categoryRepository product_repository.CategoryRepository
+	brandRepository    product_repository.BrandRepository
 	publisher          common_nats.Publisher
 }
 
@@ -33,34 +37,54 @@ func NewProductRepositoryDecorator(
 	mongoRepository product_repository.ProductRepository,
 	postgresRepository product_repository.ProductRepository,
 	redisRepository redis_repository.ProductRepository,
+	categoryRepository product_repository.CategoryRepository,
+	brandRepository product_repository.BrandRepository,
 	publisher common_nats.Publisher,
 ) *productRepositoryDecorator {
 	return &productRepositoryDecorator{
 		mongoRepository:    mongoRepository,
 		postgresRepository: postgresRepository,
 		redisRepository:    redisRepository,
+		categoryRepository: categoryRepository,
+		brandRepository:    brandRepository,
 		publisher:          publisher,
 	}
 }
 
-func (decorator *productRepositoryDecorator) GetAll(ctx context.Context, name string, page int, size int) ([]*models.Product, error) {
+func (decorator *productRepositoryDecorator) GetAll(ctx context.Context, filter *models.ProductFilter, page int, size int) ([]*models.Product, error) {
 	_, span := trace.NewSpan(ctx, "ProductRepositoryAdapter.GetAll")
 	defer span.End()
 
+	found, err := decorator.resolveCategory(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []*models.Product{}, nil
+	}
+
+	found, err = decorator.resolveBrand(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []*models.Product{}, nil
+	}
+
 	db := "redis"
-	products, err := decorator.redisRepository.GetAll(ctx, name, page, size)
+	products, err := decorator.redisRepository.GetAll(ctx, filter, page, size)
 	if err != nil {
 		fmt.Println("err redis: ", err)
 	}
 	if len(products) == 0 {
 		db = "mongo"
-		products, err = decorator.mongoRepository.GetAll(ctx, name, page, size)
+		products, err = decorator.mongoRepository.GetAll(ctx, filter, page, size)
 		if err != nil {
 			fmt.Println("err mongo: ", err)
 		}
 		if len(products) == 0 {
 			db = "postgres"
-			products, err = decorator.postgresRepository.GetAll(ctx, name, page, size)
+			products, err = decorator.postgresRepository.GetAll(ctx, filter, page, size)
 			if err != nil {
 				fmt.Println("err postgres: ", err)
 			}
@@ -68,7 +92,7 @@ func (decorator *productRepositoryDecorator) GetAll(ctx context.Context, name st
 	}
 
 	// db := "redis"
-	// products, err := decorator.redisRepository.GetAll(ctx, name, page, size)
+	// products, err := decorator.redisRepository.GetAll(ctx, filter, page, size)
 
 	fmt.Println("page: ", page)
 	fmt.Println(db)
@@ -129,22 +153,133 @@ func (decorator *productRepositoryDecorator) FindBySlug(ctx context.Context, slu
 	return product, err
 }
 
+func (decorator *productRepositoryDecorator) GetRelated(ctx context.Context, product *models.Product, relationType string, status string) ([]*models.Product, error) {
+	_, span := trace.NewSpan(ctx, "ProductRepositoryAdapter.GetRelated")
+	defer span.End()
+
+	relations := []*models.ProductRelation{}
+	for _, relation := range product.Relations {
+		if len(relationType) == 0 || relation.Type == relationType {
+			relations = append(relations, relation)
+		}
+	}
+	if len(relations) == 0 {
+		return []*models.Product{}, nil
+	}
+
+	filter := &models.ProductFilter{
+		Status: status,
+	}
+	for _, relation := range relations {
+		filter.IDs = append(filter.IDs, relation.ProductID)
+	}
+
+	products, err := decorator.GetAll(ctx, filter, 1, len(filter.IDs))
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[uuid.UUID]*models.Product{}
+	for _, related := range products {
+		found[related.ID] = related
+	}
+
+	related := []*models.Product{}
+	for _, relation := range relations {
+		if _product, ok := found[relation.ProductID]; ok {
+			related = append(related, _product)
+		}
+	}
+
+	return related, nil
+}
+
+func (decorator *productRepositoryDecorator) resolveCategory(ctx context.Context, filter *models.ProductFilter) (bool, error) {
+	category := strings.TrimSpace(filter.Category)
+	if len(category) == 0 {
+		return true, nil
+	}
+
+	var categoryModel *models.Category
+	var err error
+	ID, parseErr := uuid.Parse(category)
+	if parseErr == nil {
+		categoryModel, err = decorator.categoryRepository.FindByID(ctx, ID)
+	} else {
+		categoryModel, err = decorator.categoryRepository.FindBySlug(ctx, category)
+	}
+	if err != nil {
+		return false, err
+	}
+	if categoryModel == nil {
+		return false, nil
+	}
+
+	filter.CategoryIDs, err = decorator.categoryRepository.GetDescendantIDs(ctx, categoryModel.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (decorator *productRepositoryDecorator) resolveBrand(ctx context.Context, filter *models.ProductFilter) (bool, error) {
+	brand := strings.TrimSpace(filter.Brand)
+	if len(brand) == 0 {
+		return true, nil
+	}
+
+	var brandModel *models.Brand
+	var err error
+	ID, parseErr := uuid.Parse(brand)
+	if parseErr == nil {
+		brandModel, err = decorator.brandRepository.FindByID(ctx, ID)
+	} else {
+		brandModel, err = decorator.brandRepository.FindBySlug(ctx, brand)
+	}
+	if err != nil {
+		return false, err
+	}
+	if brandModel == nil {
+		return false, nil
+	}
+
+	filter.BrandID = uuid.NullUUID{UUID: brandModel.ID, Valid: true}
+
+	return true, nil
+}
+
 func (decorator *productRepositoryDecorator) updateRepositories(ctx context.Context, product *models.Product) error {
 	_, span := trace.NewSpan(ctx, "ProductController.updateRepositories")
 	defer span.End()
 
 	createProductCommand := &command_product.CreateProductCommand{
-		ID:          product.ID,
-		Name:        product.Name,
-		Slug:        product.Slug,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity,
-		Image:       product.Image,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-		Version:     product.Version,
-		Deleted:     product.Deleted,
+		ID:           product.ID,
+		Name:         product.Name,
+		Slug:         product.Slug,
+		Description:  product.Description,
+		Price:        product.Price,
+		Quantity:     product.Quantity,
+		Image:        product.Image,
+		Categories:   product.Categories,
+		BrandID:      product.BrandID,
+		Options:      product.Options,
+		Variants:     product.Variants,
+		Prices:       product.Prices,
+		Promotions:   product.Promotions,
+		Status:       product.Status,
+		PublishAt:    product.PublishAt,
+		Translations: product.Translations,
+		Attributes:   product.Attributes,
+		Type:         product.Type,
+		Components:   product.Components,
+		Relations:    product.Relations,
+		Rating:       product.Rating,
+		ReviewCount:  product.ReviewCount,
+		CreatedAt:    product.CreatedAt,
+		UpdatedAt:    product.UpdatedAt,
+		Version:      product.Version,
+		Deleted:      product.Deleted,
 	}
 
 	data, err := json.Marshal(createProductCommand)
@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type AddAttribute struct {
+	ID     uuid.UUID `json:"id"`
+	Code   string    `json:"code"`
+	Name   string    `json:"name"`
+	Type   string    `json:"type"`
+	Values []string  `json:"values,omitempty"`
+	Unit   string    `json:"unit,omitempty"`
+}
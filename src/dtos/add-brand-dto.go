@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type AddBrand struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Logo        string    `json:"logo,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
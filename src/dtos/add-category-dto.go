@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type AddCategory struct {
+	ID          uuid.UUID     `json:"id"`
+	ParentID    uuid.NullUUID `json:"parentid"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description,omitempty"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type AddPriceList struct {
+	ID       uuid.UUID `json:"id"`
+	Code     string    `json:"code"`
+	Currency string    `json:"currency"`
+	Channel  string    `json:"channel,omitempty"`
+}
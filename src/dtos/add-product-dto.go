@@ -1,13 +1,31 @@
 package dtos
 
-import "github.com/google/uuid"
+import (
+	"product/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type AddProduct struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description,omitempty"`
-	Price       float32   `json:"price"`
-	Quantity    uint      `json:"quantity"`
-	Image       string    `json:"image,omitempty"`
+	ID           uuid.UUID                    `json:"id"`
+	Name         string                       `json:"name"`
+	Slug         string                       `json:"slug"`
+	Description  string                       `json:"description,omitempty"`
+	Price        models.Money                 `json:"price"`
+	Quantity     uint                         `json:"quantity"`
+	Image        string                       `json:"image,omitempty"`
+	Categories   []uuid.UUID                  `json:"categories,omitempty"`
+	BrandID      uuid.NullUUID                `json:"brandid"`
+	Options      []*models.ProductOption      `json:"options,omitempty"`
+	Variants     []*models.ProductVariant     `json:"variants,omitempty"`
+	Prices       []*models.ProductPrice       `json:"prices,omitempty"`
+	Promotions   []*models.ProductPromotion   `json:"promotions,omitempty"`
+	Status       string                       `json:"status"`
+	PublishAt    time.Time                    `json:"publish_at,omitempty"`
+	Translations []*models.ProductTranslation `json:"translations,omitempty"`
+	Attributes   []*models.ProductAttribute   `json:"attributes,omitempty"`
+	Type         string                       `json:"type"`
+	Components   []*models.ProductComponent   `json:"components,omitempty"`
+	Relations    []*models.ProductRelation    `json:"relations,omitempty"`
 }
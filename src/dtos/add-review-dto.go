@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type AddReview struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"productid"`
+	UserID    string    `json:"userid"`
+	Rating    uint      `json:"rating"`
+	Title     string    `json:"title,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeProductStatus struct {
+	ID        uuid.UUID `json:"id"`
+	Status    string    `json:"status"`
+	PublishAt time.Time `json:"publish_at,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type ImportProducts struct {
+	ID     uuid.UUID `json:"id"`
+	Format string    `json:"format"`
+	Size   int       `json:"size"`
+}
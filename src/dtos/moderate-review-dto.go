@@ -0,0 +1,9 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type ModerateReview struct {
+	ID      uuid.UUID `json:"id"`
+	Status  string    `json:"status"`
+	Version uint      `json:"version"`
+}
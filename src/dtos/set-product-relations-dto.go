@@ -0,0 +1,13 @@
+package dtos
+
+import (
+	"product/src/models"
+
+	"github.com/google/uuid"
+)
+
+type SetProductRelations struct {
+	ID        uuid.UUID                 `json:"id"`
+	Relations []*models.ProductRelation `json:"relations"`
+	Version   uint                      `json:"version"`
+}
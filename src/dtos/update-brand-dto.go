@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type UpdateBrand struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Logo        string    `json:"logo,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Version     uint      `json:"version"`
+}
@@ -0,0 +1,12 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type UpdateCategory struct {
+	ID          uuid.UUID     `json:"id"`
+	ParentID    uuid.NullUUID `json:"parentid"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description,omitempty"`
+	Version     uint          `json:"version"`
+}
@@ -0,0 +1,11 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type UpdatePriceList struct {
+	ID       uuid.UUID `json:"id"`
+	Code     string    `json:"code"`
+	Currency string    `json:"currency"`
+	Channel  string    `json:"channel,omitempty"`
+	Version  uint      `json:"version"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+import "github.com/google/uuid"
+
+type WriteOffStore struct {
+	ProductID uuid.UUID `json:"productid"`
+	Quantity  uint      `json:"quantity"`
+	Reason    string    `json:"reason"`
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"product/src/data/repositories/interfaces"
+	"product/src/models"
+)
+
+func Run(repository interfaces.ProductExportRepository, format string, deleted bool, output string) {
+	if len(output) == 0 {
+		output = "products." + format
+	}
+
+	err := handler(repository, format, deleted, output)
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	fmt.Printf("Export done: %s\n", output)
+}
+
+func handler(repository interfaces.ProductExportRepository, format string, deleted bool, output string) error {
+	ctx := context.Background()
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := NewProductWriter(file, format)
+	if err != nil {
+		return err
+	}
+
+	err = repository.Export(ctx, deleted, func(product *models.Product) error {
+		return writer.Write(product)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
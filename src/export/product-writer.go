@@ -0,0 +1,161 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"product/src/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ProductWriter interface {
+	Write(product *models.Product) error
+	Close() error
+}
+
+var csvHeader = []string{
+	"id",
+	"name",
+	"slug",
+	"description",
+	"price",
+	"currency",
+	"quantity",
+	"image",
+	"status",
+	"type",
+	"brandid",
+	"categories",
+	"publish_at",
+	"rating",
+	"review_count",
+	"created_at",
+	"updated_at",
+	"version",
+	"deleted",
+}
+
+var ContentTypes = map[string]string{
+	models.FormatCSV:    "text/csv",
+	models.FormatJSON:   "application/json",
+	models.FormatNDJSON: "application/x-ndjson",
+}
+
+func NewProductWriter(writer io.Writer, format string) (ProductWriter, error) {
+	switch format {
+	case models.FormatCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvProductWriter{writer: csvWriter}, nil
+	case models.FormatJSON:
+		return &jsonProductWriter{writer: writer}, nil
+	case models.FormatNDJSON:
+		return &ndjsonProductWriter{encoder: json.NewEncoder(writer)}, nil
+	default:
+		return nil, fmt.Errorf("export format %s is not supported", format)
+	}
+}
+
+type csvProductWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvProductWriter) Write(product *models.Product) error {
+	categories := make([]string, 0, len(product.Categories))
+	for _, category := range product.Categories {
+		categories = append(categories, category.String())
+	}
+
+	brandID := ""
+	if product.BrandID.Valid {
+		brandID = product.BrandID.UUID.String()
+	}
+
+	return w.writer.Write([]string{
+		product.ID.String(),
+		product.Name,
+		product.Slug,
+		product.Description,
+		product.Price.Amount.String(),
+		product.Price.Currency,
+		strconv.FormatUint(uint64(product.Quantity), 10),
+		product.Image,
+		product.Status,
+		product.Type,
+		brandID,
+		strings.Join(categories, "|"),
+		w.time(product.PublishAt),
+		strconv.FormatFloat(product.Rating, 'f', -1, 64),
+		strconv.FormatUint(uint64(product.ReviewCount), 10),
+		w.time(product.CreatedAt),
+		w.time(product.UpdatedAt),
+		strconv.FormatUint(uint64(product.Version), 10),
+		strconv.FormatBool(product.Deleted),
+	})
+}
+
+func (w *csvProductWriter) time(value time.Time) string {
+	if value.IsZero() || value.Year() <= 1900 {
+		return ""
+	}
+
+	return value.UTC().Format(time.RFC3339)
+}
+
+func (w *csvProductWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonProductWriter struct {
+	writer io.Writer
+	count  int
+}
+
+func (w *jsonProductWriter) Write(product *models.Product) error {
+	data, err := json.Marshal(product)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if w.count == 0 {
+		separator = "["
+	}
+	w.count++
+
+	_, err = io.WriteString(w.writer, separator)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.writer.Write(data)
+	return err
+}
+
+func (w *jsonProductWriter) Close() error {
+	closing := "]"
+	if w.count == 0 {
+		closing = "[]"
+	}
+
+	_, err := io.WriteString(w.writer, closing)
+	return err
+}
+
+type ndjsonProductWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonProductWriter) Write(product *models.Product) error {
+	return w.encoder.Encode(product)
+}
+
+func (w *ndjsonProductWriter) Close() error {
+	return nil
+}
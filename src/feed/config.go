@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Title               string `json:"title"`
+	Description         string `json:"description"`
+	Link                string `json:"link"`
+	ProductLinkTemplate string `json:"productLinkTemplate"`
+	ImageLinkTemplate   string `json:"imageLinkTemplate"`
+	MinutesToRegenerate int    `json:"minutesToRegenerate"`
+}
+
+// LoadConfig reads the "feed" section of settings and rejects links that are not absolute, since shopping feeds and
+// sitemaps are fetched by crawlers that can not resolve relative links.
+func LoadConfig(settings *viper.Viper) (*Config, error) {
+	config := &Config{
+		Title:               "Products",
+		MinutesToRegenerate: 60,
+	}
+
+	err := settings.UnmarshalKey("feed", config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MinutesToRegenerate <= 0 {
+		config.MinutesToRegenerate = 60
+	}
+
+	links := []struct {
+		key         string
+		value       string
+		placeholder string
+	}{
+		{"link", config.Link, ""},
+		{"productLinkTemplate", config.ProductLinkTemplate, "{slug}"},
+		{"imageLinkTemplate", config.ImageLinkTemplate, "{image}"},
+	}
+
+	for _, link := range links {
+		err = absoluteLink(link.value, link.placeholder)
+		if err != nil {
+			return nil, fmt.Errorf("feed %s %s", link.key, err)
+		}
+	}
+
+	return config, nil
+}
+
+func absoluteLink(link string, placeholder string) error {
+	resolved := link
+	if len(placeholder) > 0 {
+		if !strings.Contains(link, placeholder) {
+			return fmt.Errorf("%q must contain %s", link, placeholder)
+		}
+		resolved = strings.ReplaceAll(link, placeholder, "x")
+	}
+
+	parsed, err := url.Parse(resolved)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return fmt.Errorf("%q must be an absolute http or https url", link)
+	}
+
+	return nil
+}
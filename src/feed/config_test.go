@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"link":                "https://shop.example.com",
+			"productLinkTemplate": "https://shop.example.com/products/{slug}",
+			"imageLinkTemplate":   "https://cdn.example.com/{image}",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		value   interface{}
+		wantErr bool
+	}{
+		{"valid", "", nil, false},
+		{"missing section", "feed", nil, true},
+		{"relative link", "link", "/", true},
+		{"relative product link", "productLinkTemplate", "/products/{slug}", true},
+		{"relative image link", "imageLinkTemplate", "{image}", true},
+		{"product link without slug", "productLinkTemplate", "https://shop.example.com/products", true},
+		{"other scheme", "imageLinkTemplate", "ftp://cdn.example.com/{image}", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := viper.New()
+			values := valid()
+			if len(test.key) > 0 {
+				values[test.key] = test.value
+			}
+			if test.key != "feed" {
+				settings.Set("feed", values)
+			}
+
+			config, err := LoadConfig(settings)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("LoadConfig() = %+v, want error", config)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LoadConfig() error: %v", err)
+			}
+		})
+	}
+}
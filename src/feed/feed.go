@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"context"
+	"net/url"
+	"product/src/data/repositories/interfaces"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Feed struct {
+	config            *Config
+	productRepository interfaces.ProductFeedRepository
+	brandRepository   interfaces.BrandRepository
+	mutex             sync.RWMutex
+	googleFeed        []byte
+	sitemapFeed       []byte
+	generatedAt       time.Time
+}
+
+func NewFeed(
+	config *Config,
+	productRepository interfaces.ProductFeedRepository,
+	brandRepository interfaces.BrandRepository,
+) *Feed {
+	return &Feed{
+		config:            config,
+		productRepository: productRepository,
+		brandRepository:   brandRepository,
+	}
+}
+
+func (f *Feed) Config() *Config {
+	return f.config
+}
+
+func (f *Feed) Generate(ctx context.Context) error {
+	products, err := f.productRepository.GetFeed(ctx)
+	if err != nil {
+		return err
+	}
+
+	brands := map[string]string{}
+	allBrands, err := f.brandRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, brand := range allBrands {
+		brands[brand.ID.String()] = brand.Name
+	}
+
+	googleFeed, err := f.google(products, brands)
+	if err != nil {
+		return err
+	}
+
+	sitemapFeed, err := f.sitemap(products)
+	if err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.googleFeed = googleFeed
+	f.sitemapFeed = sitemapFeed
+	f.generatedAt = time.Now().UTC()
+
+	return nil
+}
+
+func (f *Feed) Google() ([]byte, time.Time) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.googleFeed, f.generatedAt
+}
+
+func (f *Feed) Sitemap() ([]byte, time.Time) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.sitemapFeed, f.generatedAt
+}
+
+func (f *Feed) productLink(slug string) string {
+	return strings.ReplaceAll(f.config.ProductLinkTemplate, "{slug}", url.PathEscape(slug))
+}
+
+func (f *Feed) imageLink(image string) string {
+	if len(image) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+		return image
+	}
+
+	return strings.ReplaceAll(f.config.ImageLinkTemplate, "{image}", strings.TrimPrefix(image, "/"))
+}
@@ -0,0 +1,128 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"product/src/models"
+)
+
+const (
+	googleNamespace = "http://base.google.com/ns/1.0"
+	inStock         = "in_stock"
+	outOfStock      = "out_of_stock"
+)
+
+type googleFeed struct {
+	XMLName   xml.Name      `xml:"rss"`
+	Version   string        `xml:"version,attr"`
+	Namespace string        `xml:"xmlns:g,attr"`
+	Channel   googleChannel `xml:"channel"`
+}
+
+type googleChannel struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Items       []*googleItem `xml:"item"`
+}
+
+type googleItem struct {
+	ID           string `xml:"g:id"`
+	Title        string `xml:"g:title"`
+	Description  string `xml:"g:description"`
+	Link         string `xml:"g:link"`
+	ImageLink    string `xml:"g:image_link,omitempty"`
+	Availability string `xml:"g:availability"`
+	Price        string `xml:"g:price"`
+	SalePrice    string `xml:"g:sale_price,omitempty"`
+	Brand        string `xml:"g:brand,omitempty"`
+	Condition    string `xml:"g:condition"`
+	ItemGroupID  string `xml:"g:item_group_id,omitempty"`
+	MPN          string `xml:"g:mpn,omitempty"`
+	Identifier   string `xml:"g:identifier_exists,omitempty"`
+}
+
+func (f *Feed) google(products []*models.Product, brands map[string]string) ([]byte, error) {
+	rss := &googleFeed{
+		Version:   "2.0",
+		Namespace: googleNamespace,
+		Channel: googleChannel{
+			Title:       f.config.Title,
+			Link:        f.config.Link,
+			Description: f.config.Description,
+			Items:       []*googleItem{},
+		},
+	}
+
+	for _, product := range products {
+		item := &googleItem{
+			ID:           product.ID.String(),
+			Title:        product.Name,
+			Description:  product.Description,
+			Link:         f.productLink(product.Slug),
+			ImageLink:    f.imageLink(product.Image),
+			Availability: availability(product.Quantity),
+			Price:        price(product.Price),
+			SalePrice:    salePrice(product.Price, product.SalePrice),
+			Condition:    "new",
+		}
+
+		if product.BrandID.Valid {
+			item.Brand = brands[product.BrandID.UUID.String()]
+		}
+
+		if len(item.Brand) == 0 {
+			item.Identifier = "no"
+		}
+
+		if len(product.Variants) == 0 {
+			rss.Channel.Items = append(rss.Channel.Items, item)
+			continue
+		}
+
+		for _, variant := range product.Variants {
+			variantItem := *item
+			variantItem.ID = variant.ID.String()
+			variantItem.ItemGroupID = product.ID.String()
+			variantItem.MPN = variant.SKU
+			variantItem.Availability = availability(variant.Quantity)
+			if variant.Price != nil {
+				variantItem.Price = price(*variant.Price)
+				variantItem.SalePrice = ""
+			}
+
+			rss.Channel.Items = append(rss.Channel.Items, &variantItem)
+		}
+	}
+
+	return marshal(rss)
+}
+
+func availability(quantity uint) string {
+	if quantity > 0 {
+		return inStock
+	}
+
+	return outOfStock
+}
+
+func price(money models.Money) string {
+	return fmt.Sprintf("%s %s", money.Amount.StringFixed(2), money.Currency)
+}
+
+func salePrice(regular models.Money, sale *models.Money) string {
+	if sale == nil || !sale.Amount.LessThan(regular.Amount) {
+		return ""
+	}
+
+	return price(*sale)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"encoding/xml"
+	"product/src/models"
+)
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemap struct {
+	XMLName   xml.Name      `xml:"urlset"`
+	Namespace string        `xml:"xmlns,attr"`
+	URLs      []*sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func (f *Feed) sitemap(products []*models.Product) ([]byte, error) {
+	urlset := &sitemap{
+		Namespace: sitemapNamespace,
+		URLs:      []*sitemapURL{},
+	}
+
+	for _, product := range products {
+		lastMod := product.UpdatedAt
+		if lastMod.IsZero() {
+			lastMod = product.CreatedAt
+		}
+
+		slugs := []string{product.Slug}
+		for _, translation := range product.Translations {
+			if len(translation.Slug) > 0 && translation.Slug != product.Slug {
+				slugs = append(slugs, translation.Slug)
+			}
+		}
+
+		for _, slug := range slugs {
+			url := &sitemapURL{Loc: f.productLink(slug)}
+			if !lastMod.IsZero() {
+				url.LastMod = lastMod.UTC().Format("2006-01-02")
+			}
+			urlset.URLs = append(urlset.URLs, url)
+		}
+	}
+
+	return marshal(urlset)
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"product/src/models"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+var locales, localeMatcher = newLocaleMatcher()
+
+func newLocaleMatcher() ([]string, language.Matcher) {
+	locales := []string{models.DefaultLocale}
+	for locale := range models.Locales {
+		if locale != models.DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales[1:])
+
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tags = append(tags, language.MustParse(locale))
+	}
+
+	return locales, language.NewMatcher(tags)
+}
+
+func MatchLocale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return models.DefaultLocale
+	}
+
+	_, index, confidence := localeMatcher.Match(tags...)
+	if confidence == language.No {
+		return models.DefaultLocale
+	}
+
+	return locales[index]
+}
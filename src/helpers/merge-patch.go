@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+var ErrInvalidMergePatch = errors.New("merge patch must be a json object")
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to a JSON document.
+func MergePatch(document []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	err := json.Unmarshal(document, &target)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes interface{}
+	err = json.Unmarshal(patch, &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeValue(target, changes))
+}
+
+// CreateMergePatch returns the merge patch that turns original into modified.
+func CreateMergePatch(original []byte, modified []byte) ([]byte, error) {
+	source := map[string]interface{}{}
+	err := json.Unmarshal(original, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	target := map[string]interface{}{}
+	err = json.Unmarshal(modified, &target)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(diffObject(source, target))
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+func diffObject(source map[string]interface{}, target map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key := range source {
+		if _, ok := target[key]; !ok {
+			patch[key] = nil
+		}
+	}
+
+	for key, value := range target {
+		current, ok := source[key]
+		if ok && reflect.DeepEqual(current, value) {
+			continue
+		}
+
+		currentObject, currentIsObject := current.(map[string]interface{})
+		valueObject, valueIsObject := value.(map[string]interface{})
+		if ok && currentIsObject && valueIsObject {
+			patch[key] = diffObject(currentObject, valueObject)
+			continue
+		}
+
+		patch[key] = value
+	}
+
+	return patch
+}
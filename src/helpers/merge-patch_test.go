@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		want     string
+	}{
+		{"replace value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add key", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null deletes key", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"null on missing key", `{"a":"b"}`, `{"c":null}`, `{"a":"b"}`},
+		{"array replaced", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"object replaces array", `{"a":["b"]}`, `{"a":{"b":"c"}}`, `{"a":{"b":"c"}}`},
+		{"nested merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"f","d":null}}`, `{"a":{"b":"f"}}`},
+		{"nested null in new object", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"object replaces scalar", `{"a":"b"}`, `{"a":{"c":"d"}}`, `{"a":{"c":"d"}}`},
+		{"empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+		{"non-object patch replaces target", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"null patch replaces target", `{"a":"b"}`, `null`, `null`},
+		{"object patch on non-object target", `["a"]`, `{"a":"b"}`, `{"a":"b"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := MergePatch([]byte(test.document), []byte(test.patch))
+			if err != nil {
+				t.Fatalf("MergePatch(%s, %s) error: %v", test.document, test.patch, err)
+			}
+
+			assertJSON(t, merged, test.want)
+		})
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	_, err := MergePatch([]byte(`{"a":"b"}`), []byte(`{"a":`))
+	if err == nil {
+		t.Fatal("MergePatch with invalid patch returned no error")
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		modified string
+		want     string
+	}{
+		{"unchanged", `{"a":"b"}`, `{"a":"b"}`, `{}`},
+		{"changed value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"removed key", `{"a":"b","c":"d"}`, `{"a":"b"}`, `{"c":null}`},
+		{"nested change", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"c","d":"f"}}`, `{"a":{"d":"f"}}`},
+		{"array change", `{"a":[1,2]}`, `{"a":[2]}`, `{"a":[2]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			patch, err := CreateMergePatch([]byte(test.original), []byte(test.modified))
+			if err != nil {
+				t.Fatalf("CreateMergePatch(%s, %s) error: %v", test.original, test.modified, err)
+			}
+
+			assertJSON(t, patch, test.want)
+
+			merged, err := MergePatch([]byte(test.original), patch)
+			if err != nil {
+				t.Fatalf("MergePatch(%s, %s) error: %v", test.original, patch, err)
+			}
+
+			assertJSON(t, merged, test.modified)
+		})
+	}
+}
+
+func assertJSON(t *testing.T, actual []byte, want string) {
+	t.Helper()
+
+	var actualValue, wantValue interface{}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		t.Fatalf("invalid json %s: %v", actual, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid json %s: %v", want, err)
+	}
+
+	if !reflect.DeepEqual(actualValue, wantValue) {
+		t.Errorf("got %s, want %s", actual, want)
+	}
+}
@@ -0,0 +1,32 @@
+package helpers
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"simple", "Blue Shirt", "blue-shirt"},
+		{"accents", "Café com Pão", "cafe-com-pao"},
+		{"cedilla and tilde", "Ação Coração", "acao-coracao"},
+		{"umlauts", "Über Möbel", "uber-mobel"},
+		{"repeated separators", "blue  --  shirt__xl", "blue-shirt-xl"},
+		{"leading and trailing separators", "  --Blue Shirt!!  ", "blue-shirt"},
+		{"digits", "iPhone 15 Pro", "iphone-15-pro"},
+		{"non latin only", "日本語", ""},
+		{"mixed non latin", "Tee 日本 2024", "tee-2024"},
+		{"punctuation only", "!!! ---", ""},
+		{"empty", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Slugify(test.value)
+			if got != test.want {
+				t.Errorf("Slugify(%q) = %q, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
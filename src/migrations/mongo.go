@@ -0,0 +1,109 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"product/src/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func RunMongo(database *mongo.Database) {
+	ctx := context.Background()
+
+	err := migrateProductPrices(ctx, database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = migrateProductStatus(ctx, database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = migrateProductType(ctx, database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Mongo migrations done!!!")
+}
+
+func migrateProductPrices(ctx context.Context, database *mongo.Database) error {
+	money := func(price string) bson.M {
+		return bson.M{
+			"$cond": bson.A{
+				bson.M{"$isNumber": price},
+				bson.M{
+					"amount":   bson.M{"$round": bson.A{bson.M{"$toDecimal": price}, 2}},
+					"currency": models.DefaultCurrency,
+				},
+				price,
+			},
+		}
+	}
+
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"price": bson.M{"$type": "number"}},
+			bson.M{"variants.price": bson.M{"$type": "number"}},
+		},
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"price": money("$price"),
+			"variants": bson.M{
+				"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$variants", bson.A{}}},
+					"as":    "variant",
+					"in": bson.M{
+						"$mergeObjects": bson.A{
+							"$$variant",
+							bson.M{"price": money("$$variant.price")},
+						},
+					},
+				},
+			},
+		}}},
+	}
+
+	result, err := database.Collection("products").UpdateMany(ctx, filter, pipeline)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("product prices migrated: %d", result.ModifiedCount)
+
+	return nil
+}
+
+func migrateProductStatus(ctx context.Context, database *mongo.Database) error {
+	filter := bson.M{"status": bson.M{"$exists": false}}
+	fields := bson.M{"$set": bson.M{"status": models.ProductPublished}}
+
+	result, err := database.Collection("products").UpdateMany(ctx, filter, fields)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("product status migrated: %d", result.ModifiedCount)
+
+	return nil
+}
+
+func migrateProductType(ctx context.Context, database *mongo.Database) error {
+	filter := bson.M{"type": bson.M{"$exists": false}}
+	fields := bson.M{"$set": bson.M{"type": models.ProductSimple}}
+
+	result, err := database.Collection("products").UpdateMany(ctx, filter, fields)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("product type migrated: %d", result.ModifiedCount)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+	AttributeEnum    = "enum"
+)
+
+type Attribute struct {
+	ID        uuid.UUID `bson:"_id" json:"id"`
+	Code      string    `bson:"code" json:"code"`
+	Name      string    `bson:"name" json:"name"`
+	Type      string    `bson:"type" json:"type"`
+	Values    []string  `bson:"values" json:"values,omitempty"`
+	Unit      string    `bson:"unit" json:"unit,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at,omitempty"`
+	Version   uint      `bson:"version" json:"version"`
+	Deleted   bool      `bson:"deleted" json:"deleted,omitempty"`
+}
+
+func (attribute *Attribute) Allows(value string) bool {
+	for _, allowed := range attribute.Values {
+		if allowed == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Brand struct {
+	ID          uuid.UUID `bson:"_id" json:"id"`
+	Name        string    `bson:"name" json:"name"`
+	Slug        string    `bson:"slug" json:"slug"`
+	Logo        string    `bson:"logo" json:"logo,omitempty"`
+	Description string    `bson:"description" json:"description,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at,omitempty"`
+	Version     uint      `bson:"version" json:"version"`
+	Deleted     bool      `bson:"deleted" json:"deleted,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	ID          uuid.UUID     `bson:"_id" json:"id"`
+	ParentID    uuid.NullUUID `bson:"parent_id" json:"parentid"`
+	Name        string        `bson:"name" json:"name"`
+	Slug        string        `bson:"slug" json:"slug"`
+	Description string        `bson:"description" json:"description,omitempty"`
+	Children    []*Category   `bson:"-" json:"children,omitempty"`
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time     `bson:"updated_at" json:"updated_at,omitempty"`
+	Version     uint          `bson:"version" json:"version"`
+	Deleted     bool          `bson:"deleted" json:"deleted,omitempty"`
+}
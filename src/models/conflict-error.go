@@ -0,0 +1,13 @@
+package models
+
+type ConflictError struct {
+	message string
+}
+
+func NewConflictError(message string) *ConflictError {
+	return &ConflictError{message: message}
+}
+
+func (e *ConflictError) Error() string {
+	return e.message
+}
@@ -0,0 +1,17 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+const DefaultCurrency = "BRL"
+
+type Money struct {
+	Amount   decimal.Decimal `bson:"amount" json:"amount"`
+	Currency string          `bson:"currency" json:"currency"`
+}
+
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
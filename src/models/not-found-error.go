@@ -0,0 +1,13 @@
+package models
+
+type NotFoundError struct {
+	message string
+}
+
+func NewNotFoundError(message string) *NotFoundError {
+	return &NotFoundError{message: message}
+}
+
+func (e *NotFoundError) Error() string {
+	return e.message
+}
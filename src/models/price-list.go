@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceList struct {
+	ID        uuid.UUID `bson:"_id" json:"id"`
+	Code      string    `bson:"code" json:"code"`
+	Currency  string    `bson:"currency" json:"currency"`
+	Channel   string    `bson:"channel" json:"channel,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at,omitempty"`
+	Version   uint      `bson:"version" json:"version"`
+	Deleted   bool      `bson:"deleted" json:"deleted,omitempty"`
+}
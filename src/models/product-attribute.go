@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type ProductAttribute struct {
+	AttributeID uuid.UUID   `bson:"attributeid" json:"attributeid"`
+	Code        string      `bson:"code" json:"code"`
+	Type        string      `bson:"type" json:"type"`
+	Value       interface{} `bson:"value" json:"value"`
+	Definition  *Attribute  `bson:"-" json:"-"`
+}
+
+type AttributeFilter struct {
+	Code  string   `json:"code"`
+	Value string   `json:"value,omitempty"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+func (filter *AttributeFilter) Range() bool {
+	return filter.Min != nil || filter.Max != nil
+}
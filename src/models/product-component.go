@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	ProductSimple = "simple"
+	ProductBundle = "bundle"
+)
+
+type ProductComponent struct {
+	ProductID uuid.UUID     `bson:"productid" json:"productid"`
+	VariantID uuid.NullUUID `bson:"variantid" json:"variantid"`
+	Quantity  uint          `bson:"quantity" json:"quantity"`
+}
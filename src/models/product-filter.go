@@ -0,0 +1,27 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	ProductSortName       = "name"
+	ProductSortRating     = "rating"
+	ProductSortRatingDesc = "-rating"
+)
+
+type ProductFilter struct {
+	IDs         []uuid.UUID
+	Name        string
+	Category    string
+	CategoryIDs []uuid.UUID
+	Brand       string
+	BrandID     uuid.NullUUID
+	SKU         string
+	Status      string
+	Locale      string
+	Attributes  []*AttributeFilter
+	MinRating   float64
+	Sort        string
+	// products priced in PriceListID or, when Currency is set, with a base price in Currency
+	PriceListID uuid.NullUUID
+	Currency    string
+}
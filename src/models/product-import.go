@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ImportMaxSize = 32 << 20
+	ImportMaxRows = 10000
+)
+
+const (
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+)
+
+type ProductImport struct {
+	ID         uuid.UUID           `bson:"_id" json:"id"`
+	Format     string              `bson:"format" json:"format"`
+	Status     string              `bson:"status" json:"status"`
+	Total      uint                `bson:"total" json:"total"`
+	Succeeded  uint                `bson:"succeeded" json:"succeeded"`
+	Failed     uint                `bson:"failed" json:"failed"`
+	Rows       []*ProductImportRow `bson:"rows" json:"rows"`
+	CreatedBy  string              `bson:"created_by" json:"created_by,omitempty"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+	FinishedAt time.Time           `bson:"finished_at" json:"finished_at,omitempty"`
+}
+
+type ProductImportRow struct {
+	Row       uint      `bson:"row" json:"row"`
+	ProductID uuid.UUID `bson:"productid" json:"productid,omitempty"`
+	Action    string    `bson:"action" json:"action,omitempty"`
+	Error     string    `bson:"error" json:"error,omitempty"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "github.com/google/uuid"
+
+type ProductPrice struct {
+	PriceListID uuid.UUID `bson:"pricelistid" json:"pricelistid"`
+	Price       Money     `bson:"price" json:"price"`
+}
+
+type PriceSelector struct {
+	PriceList string
+	Currency  string
+	Channel   string
+}
+
+func ListPrice(prices []*ProductPrice, priceListID uuid.UUID) *Money {
+	for _, price := range prices {
+		if price.PriceListID == priceListID {
+			value := price.Price
+			return &value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductPromotion struct {
+	ID          uuid.UUID     `bson:"id" json:"id"`
+	PriceListID uuid.NullUUID `bson:"pricelistid" json:"pricelistid"`
+	Price       Money         `bson:"price" json:"price"`
+	StartsAt    time.Time     `bson:"starts_at" json:"starts_at"`
+	EndsAt      time.Time     `bson:"ends_at" json:"ends_at"`
+}
+
+// ActivePromotion returns the cheapest running promotion on the base price. Promotions scoped to a price list
+// only apply when that list is selected.
+func ActivePromotion(promotions []*ProductPromotion, now time.Time) *ProductPromotion {
+	return activePromotion(promotions, uuid.NullUUID{}, now)
+}
+
+func SalePrice(promotions []*ProductPromotion, now time.Time) *Money {
+	return salePrice(ActivePromotion(promotions, now))
+}
+
+func PriceListSalePrice(promotions []*ProductPromotion, priceListID uuid.UUID, now time.Time) *Money {
+	return salePrice(activePromotion(promotions, uuid.NullUUID{UUID: priceListID, Valid: true}, now))
+}
+
+func activePromotion(promotions []*ProductPromotion, priceListID uuid.NullUUID, now time.Time) *ProductPromotion {
+	var active *ProductPromotion
+	for _, promotion := range promotions {
+		if promotion.PriceListID != priceListID {
+			continue
+		}
+
+		if promotion.StartsAt.After(now) || !promotion.EndsAt.After(now) {
+			continue
+		}
+
+		if active == nil || promotion.Price.Amount.LessThan(active.Price.Amount) {
+			active = promotion
+		}
+	}
+
+	return active
+}
+
+func salePrice(promotion *ProductPromotion) *Money {
+	if promotion == nil {
+		return nil
+	}
+
+	price := promotion.Price
+	return &price
+}
@@ -0,0 +1,9 @@
+package models
+
+import "github.com/google/uuid"
+
+type ProductRating struct {
+	ProductID   uuid.UUID `json:"productid"`
+	Rating      float64   `json:"rating"`
+	ReviewCount uint      `json:"review_count"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	RelationRelated   = "related"
+	RelationAccessory = "accessory"
+	RelationUpsell    = "upsell"
+)
+
+type ProductRelation struct {
+	ProductID uuid.UUID `bson:"productid" json:"productid"`
+	Type      string    `bson:"type" json:"type"`
+	Position  uint      `bson:"position" json:"position"`
+}
@@ -0,0 +1,8 @@
+package models
+
+const (
+	ProductDraft     = "draft"
+	ProductScheduled = "scheduled"
+	ProductPublished = "published"
+	ProductArchived  = "archived"
+)
@@ -0,0 +1,28 @@
+package models
+
+import "strings"
+
+const DefaultLocale = "pt-BR"
+
+var Locales = map[string]string{
+	"pt-BR": "portuguese",
+	"en-US": "english",
+	"es-ES": "spanish",
+}
+
+type ProductTranslation struct {
+	Locale      string `bson:"locale" json:"locale"`
+	Name        string `bson:"name" json:"name"`
+	Slug        string `bson:"slug" json:"slug"`
+	Description string `bson:"description" json:"description,omitempty"`
+}
+
+func Translation(translations []*ProductTranslation, locale string) *ProductTranslation {
+	for _, translation := range translations {
+		if strings.EqualFold(translation.Locale, locale) {
+			return translation
+		}
+	}
+
+	return nil
+}
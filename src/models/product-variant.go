@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductOption struct {
+	Name   string   `bson:"name" json:"name"`
+	Values []string `bson:"values" json:"values"`
+}
+
+type ProductVariant struct {
+	ID        uuid.UUID         `bson:"id" json:"id"`
+	ProductID uuid.UUID         `bson:"product_id" json:"productid"`
+	SKU       string            `bson:"sku" json:"sku"`
+	Options   map[string]string `bson:"options" json:"options"`
+	Price     *Money            `bson:"price" json:"price,omitempty"`
+	Prices    []*ProductPrice   `bson:"prices" json:"prices,omitempty"`
+	Quantity  uint              `bson:"-" json:"quantity"`
+	CreatedAt time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at" json:"updated_at,omitempty"`
+	Version   uint              `bson:"version" json:"version"`
+	Deleted   bool              `bson:"deleted" json:"deleted,omitempty"`
+}
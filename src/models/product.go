@@ -7,15 +7,31 @@ import (
 )
 
 type Product struct {
-	ID          uuid.UUID `bson:"_id" json:"id"`
-	Name        string    `bson:"name" json:"name"`
-	Slug        string    `bson:"slug" json:"slug"`
-	Description string    `bson:"description" json:"description"`
-	Price       float32   `bson:"price" json:"price"`
-	Quantity    uint      `json:"quantity,omitempty"`
-	Image       string    `bson:"image" json:"image,omitempty"`
-	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at,omitempty"`
-	Version     uint      `bson:"version" json:"version"`
-	Deleted     bool      `bson:"deleted" json:"deleted,omitempty"`
+	ID           uuid.UUID             `bson:"_id" json:"id"`
+	Name         string                `bson:"name" json:"name"`
+	Slug         string                `bson:"slug" json:"slug"`
+	Description  string                `bson:"description" json:"description"`
+	Price        Money                 `bson:"price" json:"price"`
+	Quantity     uint                  `json:"quantity,omitempty"`
+	Image        string                `bson:"image" json:"image,omitempty"`
+	Categories   []uuid.UUID           `bson:"categories" json:"categories,omitempty"`
+	BrandID      uuid.NullUUID         `bson:"brandid" json:"brandid"`
+	Options      []*ProductOption      `bson:"options" json:"options,omitempty"`
+	Variants     []*ProductVariant     `bson:"variants" json:"variants,omitempty"`
+	Prices       []*ProductPrice       `bson:"prices" json:"prices,omitempty"`
+	Promotions   []*ProductPromotion   `bson:"promotions" json:"promotions,omitempty"`
+	SalePrice    *Money                `bson:"sale_price" json:"sale_price,omitempty"`
+	Status       string                `bson:"status" json:"status"`
+	PublishAt    time.Time             `bson:"publish_at" json:"publish_at,omitempty"`
+	Translations []*ProductTranslation `bson:"translations" json:"translations,omitempty"`
+	Attributes   []*ProductAttribute   `bson:"attributes" json:"attributes,omitempty"`
+	Type         string                `bson:"type" json:"type"`
+	Components   []*ProductComponent   `bson:"components" json:"components,omitempty"`
+	Relations    []*ProductRelation    `bson:"relations" json:"relations,omitempty"`
+	Rating       float64               `bson:"rating" json:"rating"`
+	ReviewCount  uint                  `bson:"review_count" json:"review_count"`
+	CreatedAt    time.Time             `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time             `bson:"updated_at" json:"updated_at,omitempty"`
+	Version      uint                  `bson:"version" json:"version"`
+	Deleted      bool                  `bson:"deleted" json:"deleted,omitempty"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReservationActive   = "active"
+	ReservationPaid     = "paid"
+	ReservationReleased = "released"
+	ReservationExpired  = "expired"
+)
+
+type Reservation struct {
+	ID         uuid.UUID       `json:"id"`
+	OrderID    string          `json:"orderid"`
+	CustomerID string          `json:"customerid,omitempty"`
+	Lines      []*StoreBooking `json:"lines"`
+	Status     string          `json:"status"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	Version    uint            `json:"version"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+type Review struct {
+	ID        uuid.UUID `bson:"_id" json:"id"`
+	ProductID uuid.UUID `bson:"productid" json:"productid"`
+	UserID    string    `bson:"userid" json:"userid"`
+	Rating    uint      `bson:"rating" json:"rating"`
+	Title     string    `bson:"title" json:"title,omitempty"`
+	Comment   string    `bson:"comment" json:"comment,omitempty"`
+	Status    string    `bson:"status" json:"status"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at,omitempty"`
+	Version   uint      `bson:"version" json:"version"`
+	Deleted   bool      `bson:"deleted" json:"deleted,omitempty"`
+}
+
+type ReviewFilter struct {
+	ProductID uuid.NullUUID
+	Status    string
+}
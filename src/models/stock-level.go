@@ -0,0 +1,11 @@
+package models
+
+import "github.com/google/uuid"
+
+const StockAdjustmentReason = "stock adjustment"
+
+type StockLevel struct {
+	ProductID uuid.UUID     `json:"productid"`
+	VariantID uuid.NullUUID `json:"variantid"`
+	Quantity  uint          `json:"quantity"`
+}
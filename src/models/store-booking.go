@@ -0,0 +1,11 @@
+package models
+
+import "github.com/google/uuid"
+
+type StoreBooking struct {
+	BundleID  uuid.NullUUID `json:"bundleid"`
+	ProductID uuid.UUID     `json:"productid"`
+	VariantID uuid.NullUUID `json:"variantid"`
+	Quantity  uint          `json:"quantity"`
+	Available uint          `json:"available"`
+}
@@ -29,8 +29,14 @@ var (
 	subscribe          common_nats.Listener
 	commandErrorHelper *common_nats.CommandErrorHelper
 
-	mongoProductCreateCommand *mongo_listeners.ProductCreateCommandListener
-	mongoProductUpdateCommand *mongo_listeners.ProductUpdateCommandListener
+	mongoProductCreateCommand  *mongo_listeners.ProductCreateCommandListener
+	mongoProductUpdateCommand  *mongo_listeners.ProductUpdateCommandListener
+	mongoProductDeleteCommand  *mongo_listeners.ProductDeleteCommandListener
+	mongoProductRestoreCommand *mongo_listeners.ProductRestoreCommandListener
+	mongoProductRatingCommand  *mongo_listeners.ProductRatingCommandListener
+	mongoProductPatchCommand   *mongo_listeners.ProductPatchCommandListener
+
+	postgresProductPublishCommand *postgres_listeners.ProductPublishCommandListener
 
 	postgresStoreCreateCommand *postgres_listeners.StoreCreateCommandListener
 	mongoStoreCreateCommand    *mongo_listeners.StoreCreateCommandListener
@@ -43,6 +49,10 @@ var (
 
 	postgresStorePaymentCommand *postgres_listeners.StorePaymentCommandListener
 	mongoStorePaymentCommand    *mongo_listeners.StorePaymentCommandListener
+
+	postgresStoreExtendCommand *postgres_listeners.StoreExtendCommandListener
+
+	mongoStoreWriteOffCommand *mongo_listeners.StoreWriteOffCommandListener
 )
 
 func NewListen(
@@ -59,6 +69,12 @@ func NewListen(
 
 	mongoProductCreateCommand = mongo_listeners.NewProductCreateCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
 	mongoProductUpdateCommand = mongo_listeners.NewProductUpdateCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
+	mongoProductDeleteCommand = mongo_listeners.NewProductDeleteCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
+	mongoProductRestoreCommand = mongo_listeners.NewProductRestoreCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
+	mongoProductRatingCommand = mongo_listeners.NewProductRatingCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
+	mongoProductPatchCommand = mongo_listeners.NewProductPatchCommandListener(mongoProductCommandHandler, email, commandErrorHelper)
+
+	postgresProductPublishCommand = postgres_listeners.NewProductPublishCommandListener(postgresProductCommandHandler, email, commandErrorHelper)
 
 	postgresStoreCreateCommand = postgres_listeners.NewStoreCreateCommandListener(postgresStoreCommandHandler, email, commandErrorHelper)
 	mongoStoreCreateCommand = mongo_listeners.NewStoreCreateCommandListener(mongoStoreCommandHandler, email, commandErrorHelper)
@@ -71,6 +87,10 @@ func NewListen(
 
 	postgresStorePaymentCommand = postgres_listeners.NewStorePaymentCommandListener(postgresStoreCommandHandler, email, commandErrorHelper)
 	mongoStorePaymentCommand = mongo_listeners.NewStorePaymentCommandListener(mongoStoreCommandHandler, email, commandErrorHelper)
+
+	postgresStoreExtendCommand = postgres_listeners.NewStoreExtendCommandListener(postgresStoreCommandHandler, email, commandErrorHelper)
+
+	mongoStoreWriteOffCommand = mongo_listeners.NewStoreWriteOffCommandListener(mongoStoreCommandHandler, email, commandErrorHelper)
 	return &listen{
 		js: js,
 	}
@@ -97,5 +117,19 @@ func (l *listen) Listen() {
 
 	go subscribe.Listener(string(subjects.StorePaymentMongo), queueGroupName, queueGroupName+"_9", mongoStorePaymentCommand.ProcessStorePaymentCommand())
 
+	go subscribe.Listener(string(subjects.ProductPublishPostgres), queueGroupName, queueGroupName+"_10", postgresProductPublishCommand.ProcessProductPublishCommand())
+
+	go subscribe.Listener(string(subjects.ProductDeleteMongo), queueGroupName, queueGroupName+"_11", mongoProductDeleteCommand.ProcessProductDeleteCommand())
+
+	go subscribe.Listener(string(subjects.ProductRestoreMongo), queueGroupName, queueGroupName+"_12", mongoProductRestoreCommand.ProcessProductRestoreCommand())
+
+	go subscribe.Listener(string(subjects.ProductRatingMongo), queueGroupName, queueGroupName+"_13", mongoProductRatingCommand.ProcessProductRatingCommand())
+
+	go subscribe.Listener(string(subjects.ProductPatchMongo), queueGroupName, queueGroupName+"_14", mongoProductPatchCommand.ProcessProductPatchCommand())
+
+	go subscribe.Listener(string(subjects.StoreWriteOffMongo), queueGroupName, queueGroupName+"_15", mongoStoreWriteOffCommand.ProcessStoreWriteOffCommand())
+
+	go subscribe.Listener(string(subjects.StoreExtendPostgres), queueGroupName, queueGroupName+"_16", postgresStoreExtendCommand.ProcessStoreExtendCommand())
+
 	log.Printf("Listener on!!!\n")
 }
@@ -0,0 +1,54 @@
+package mongo_listeners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	command "product/src/application/commands/product"
+	mongo_command_handler "product/src/application/commands/product/mongo"
+
+	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
+	common_service "github.com/JohnSalazar/microservices-go-common/services"
+	"github.com/nats-io/nats.go"
+
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+)
+
+type ProductPatchCommandListener struct {
+	mongoProductCommandHandler *mongo_command_handler.ProductCommandHandler
+	email                      common_service.EmailService
+	errorHelper                *common_nats.CommandErrorHelper
+}
+
+func NewProductPatchCommandListener(
+	mongoProductCommandHandler *mongo_command_handler.ProductCommandHandler,
+	email common_service.EmailService,
+	errorHelper *common_nats.CommandErrorHelper,
+) *ProductPatchCommandListener {
+	return &ProductPatchCommandListener{
+		mongoProductCommandHandler: mongoProductCommandHandler,
+		email:                      email,
+		errorHelper:                errorHelper,
+	}
+}
+
+func (c *ProductPatchCommandListener) ProcessProductPatchCommand() nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		ctx := context.Background()
+		_, span := trace.NewSpan(ctx, fmt.Sprintf("publish.%s\n", msg.Subject))
+		defer span.End()
+
+		productCommand := &command.PatchProductCommand{}
+		err := json.Unmarshal(msg.Data, productCommand)
+		if c.errorHelper.CheckUnmarshal(msg, err) == nil {
+			err = c.mongoProductCommandHandler.PatchProductCommandHandler(ctx, productCommand)
+			c.errorHelper.CheckCommandError(span, msg, err)
+		}
+
+		err = msg.Ack()
+		if err != nil {
+			log.Printf("stan msg.Ack error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package postgres_listeners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	postgres_command "product/src/application/commands/product/postgres"
+
+	command "product/src/application/commands/product"
+
+	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
+	common_service "github.com/JohnSalazar/microservices-go-common/services"
+	"github.com/nats-io/nats.go"
+
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+)
+
+type ProductPublishCommandListener struct {
+	postgresCommandHandler *postgres_command.ProductCommandHandler
+	email                  common_service.EmailService
+	errorHelper            *common_nats.CommandErrorHelper
+}
+
+func NewProductPublishCommandListener(
+	postgresCommandHandler *postgres_command.ProductCommandHandler,
+	email common_service.EmailService,
+	errorHelper *common_nats.CommandErrorHelper,
+) *ProductPublishCommandListener {
+	return &ProductPublishCommandListener{
+		postgresCommandHandler: postgresCommandHandler,
+		email:                  email,
+		errorHelper:            errorHelper,
+	}
+}
+
+func (c *ProductPublishCommandListener) ProcessProductPublishCommand() nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		ctx := context.Background()
+		_, span := trace.NewSpan(ctx, fmt.Sprintf("publish.%s\n", msg.Subject))
+		defer span.End()
+
+		productCommand := &command.ChangeProductStatusCommand{}
+		err := json.Unmarshal(msg.Data, productCommand)
+		if c.errorHelper.CheckUnmarshal(msg, err) == nil {
+			_, err = c.postgresCommandHandler.ChangeProductStatusCommandHandler(ctx, productCommand)
+			c.errorHelper.CheckCommandError(span, msg, err)
+		}
+
+		err = msg.Ack()
+		if err != nil {
+			log.Printf("stan msg.Ack error: %v\n", err)
+		}
+	}
+}
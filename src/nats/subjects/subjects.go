@@ -4,16 +4,25 @@ type ProductSubject string
 type StoreSubject string
 
 const (
-	ProductCreateMongo    ProductSubject = "product:create-mongo"
-	ProductCreatePostgres ProductSubject = "product:create-postgres"
-	ProductUpdateMongo    ProductSubject = "product:update-mongo"
-	StoreBookMongo        StoreSubject   = "store:book-mongo"
-	StoreCreateMongo      StoreSubject   = "store:create-mongo"
-	StoreCreatePostgres   StoreSubject   = "store:create-postgres"
-	StorePaymentMongo     StoreSubject   = "store:payment-mongo"
-	StorePaymentPostgres  StoreSubject   = "store:payment-postgres"
-	StoreUnbookMongo      StoreSubject   = "store:unbook-mongo"
-	StoreUnbookPostgres   StoreSubject   = "store:unbook-postgres"
+	ProductCreateMongo     ProductSubject = "product:create-mongo"
+	ProductCreatePostgres  ProductSubject = "product:create-postgres"
+	ProductUpdateMongo     ProductSubject = "product:update-mongo"
+	ProductPatchMongo      ProductSubject = "product:patch-mongo"
+	ProductDeleteMongo     ProductSubject = "product:delete-mongo"
+	ProductRestoreMongo    ProductSubject = "product:restore-mongo"
+	ProductPriceChanged    ProductSubject = "product:price-changed"
+	ProductPublishPostgres ProductSubject = "product:publish-postgres"
+	ProductRatingMongo     ProductSubject = "product:rating-mongo"
+	StoreBookMongo         StoreSubject   = "store:book-mongo"
+	StoreBookFailed        StoreSubject   = "store:book-failed"
+	StoreCreateMongo       StoreSubject   = "store:create-mongo"
+	StoreCreatePostgres    StoreSubject   = "store:create-postgres"
+	StoreExtendPostgres    StoreSubject   = "store:extend-postgres"
+	StorePaymentMongo      StoreSubject   = "store:payment-mongo"
+	StorePaymentPostgres   StoreSubject   = "store:payment-postgres"
+	StoreUnbookMongo       StoreSubject   = "store:unbook-mongo"
+	StoreUnbookPostgres    StoreSubject   = "store:unbook-postgres"
+	StoreWriteOffMongo     StoreSubject   = "store:write-off-mongo"
 )
 
 func GetProductSubjects() []string {
@@ -21,17 +30,26 @@ func GetProductSubjects() []string {
 		string(ProductCreateMongo),
 		string(ProductCreatePostgres),
 		string(ProductUpdateMongo),
+		string(ProductPatchMongo),
+		string(ProductDeleteMongo),
+		string(ProductRestoreMongo),
+		string(ProductPriceChanged),
+		string(ProductPublishPostgres),
+		string(ProductRatingMongo),
 	}
 }
 
 func GetStoreSubjects() []string {
 	return []string{
 		string(StoreBookMongo),
+		string(StoreBookFailed),
 		string(StoreCreateMongo),
 		string(StoreCreatePostgres),
+		string(StoreExtendPostgres),
 		string(StorePaymentMongo),
 		string(StorePaymentPostgres),
 		string(StoreUnbookMongo),
 		string(StoreUnbookPostgres),
+		string(StoreWriteOffMongo),
 	}
 }
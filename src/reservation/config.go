@@ -0,0 +1,66 @@
+package reservation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	DefaultTTLSeconds  uint `json:"defaultTtlSeconds"`
+	MinTTLSeconds      uint `json:"minTtlSeconds"`
+	MaxTTLSeconds      uint `json:"maxTtlSeconds"`
+	MaxLifetimeSeconds uint `json:"maxLifetimeSeconds"`
+}
+
+// LoadConfig reads the reservation TTL limits from the "reservation" section of settings, keeping the defaults for
+// missing keys, and rejects limits that contradict each other so a bad deploy fails at startup.
+func LoadConfig(settings *viper.Viper) (*Config, error) {
+	config := &Config{
+		DefaultTTLSeconds:  600,
+		MinTTLSeconds:      60,
+		MaxTTLSeconds:      1800,
+		MaxLifetimeSeconds: 3600,
+	}
+
+	err := settings.UnmarshalKey("reservation", config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MinTTLSeconds == 0 {
+		return nil, fmt.Errorf("reservation minTtlSeconds must be greater than zero")
+	}
+
+	if config.MaxTTLSeconds < config.MinTTLSeconds {
+		return nil, fmt.Errorf("reservation maxTtlSeconds %d must not be less than minTtlSeconds %d", config.MaxTTLSeconds, config.MinTTLSeconds)
+	}
+
+	if config.DefaultTTLSeconds < config.MinTTLSeconds || config.DefaultTTLSeconds > config.MaxTTLSeconds {
+		return nil, fmt.Errorf("reservation defaultTtlSeconds %d must be between %d and %d", config.DefaultTTLSeconds, config.MinTTLSeconds, config.MaxTTLSeconds)
+	}
+
+	if config.MaxLifetimeSeconds < config.MaxTTLSeconds {
+		return nil, fmt.Errorf("reservation maxLifetimeSeconds %d must not be less than maxTtlSeconds %d", config.MaxLifetimeSeconds, config.MaxTTLSeconds)
+	}
+
+	return config, nil
+}
+
+// TTL returns the default TTL when seconds is zero and rejects values outside the configured limits.
+func (c *Config) TTL(seconds uint) (time.Duration, error) {
+	if seconds == 0 {
+		seconds = c.DefaultTTLSeconds
+	}
+
+	if seconds < c.MinTTLSeconds || seconds > c.MaxTTLSeconds {
+		return 0, fmt.Errorf("ttl must be between %d and %d seconds", c.MinTTLSeconds, c.MaxTTLSeconds)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (c *Config) MaxLifetime() time.Duration {
+	return time.Duration(c.MaxLifetimeSeconds) * time.Second
+}
@@ -0,0 +1,42 @@
+package reservation
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		wantErr bool
+	}{
+		{"defaults", map[string]interface{}{}, false},
+		{"valid", map[string]interface{}{"defaultTtlSeconds": 300, "minTtlSeconds": 30, "maxTtlSeconds": 900, "maxLifetimeSeconds": 1800}, false},
+		{"zero min ttl", map[string]interface{}{"minTtlSeconds": 0}, true},
+		{"max ttl below min", map[string]interface{}{"minTtlSeconds": 120, "maxTtlSeconds": 60}, true},
+		{"default ttl below min", map[string]interface{}{"defaultTtlSeconds": 10}, true},
+		{"default ttl above max", map[string]interface{}{"defaultTtlSeconds": 7200}, true},
+		{"lifetime below max ttl", map[string]interface{}{"maxLifetimeSeconds": 600}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := viper.New()
+			settings.Set("reservation", test.values)
+
+			config, err := LoadConfig(settings)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("LoadConfig() = %+v, want error", config)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LoadConfig() error: %v", err)
+			}
+		})
+	}
+}
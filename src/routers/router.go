@@ -14,10 +14,18 @@ import (
 )
 
 type Router struct {
-	config            *config.Config
-	serviceMetrics    common_service.Metrics
-	authentication    *middlewares.Authentication
-	productController *controllers.ProductController
+	config                  *config.Config
+	serviceMetrics          common_service.Metrics
+	authentication          *middlewares.Authentication
+	productController       *controllers.ProductController
+	productImportController *controllers.ProductImportController
+	reservationController   *controllers.ReservationController
+	categoryController      *controllers.CategoryController
+	priceListController     *controllers.PriceListController
+	attributeController     *controllers.AttributeController
+	brandController         *controllers.BrandController
+	reviewController        *controllers.ReviewController
+	feedController          *controllers.FeedController
 }
 
 func NewRouter(
@@ -25,12 +33,28 @@ func NewRouter(
 	serviceMetrics common_service.Metrics,
 	authentication *middlewares.Authentication,
 	productController *controllers.ProductController,
+	productImportController *controllers.ProductImportController,
+	reservationController *controllers.ReservationController,
+	categoryController *controllers.CategoryController,
+	priceListController *controllers.PriceListController,
+	attributeController *controllers.AttributeController,
+	brandController *controllers.BrandController,
+	reviewController *controllers.ReviewController,
+	feedController *controllers.FeedController,
 ) *Router {
 	return &Router{
-		config:            config,
-		serviceMetrics:    serviceMetrics,
-		authentication:    authentication,
-		productController: productController,
+		config:                  config,
+		serviceMetrics:          serviceMetrics,
+		authentication:          authentication,
+		productController:       productController,
+		productImportController: productImportController,
+		reservationController:   reservationController,
+		categoryController:      categoryController,
+		priceListController:     priceListController,
+		attributeController:     attributeController,
+		brandController:         brandController,
+		reviewController:        reviewController,
+		feedController:          feedController,
 	}
 }
 
@@ -51,17 +75,135 @@ func (r *Router) RouterSetup() *gin.Engine {
 	v1.GET("/:name/:page/:size", r.productController.GetAll)
 	v1.GET("/id/:id", r.productController.GetProductById)
 	v1.GET("/slug/:slug", r.productController.GetProductBySlug)
+	v1.GET("/id/:id/related", r.productController.GetRelatedById)
+	v1.GET("/slug/:slug/related", r.productController.GetRelatedBySlug)
+	v1.GET("/admin/products/:name/:page/:size", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.GetAllAdmin)
+	v1.GET("/admin/products/id/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.GetProductByIdAdmin)
+	v1.GET("/admin/products/slug/:slug", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.GetProductBySlugAdmin)
 	v1.GET("/refresh", r.authentication.Verify(),
 		middlewares.Authorization("admin", "update"),
 		r.productController.Refresh)
 	v1.POST("/", r.authentication.Verify(),
 		middlewares.Authorization("product", "create"),
 		r.productController.AddProduct)
-	v1.POST("/book", r.authentication.Verify(), r.productController.Book)
+	v1.GET("/export", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productImportController.ExportProducts)
+	v1.POST("/import", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.productImportController.ImportProducts)
+	v1.GET("/import/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.productImportController.GetImport)
+	v1.POST("/book", r.authentication.Verify(), r.reservationController.Book)
+	v1.POST("/book/:orderId/extend", r.authentication.Verify(), r.reservationController.ExtendReservation)
 	v1.PUT("/:id", r.authentication.Verify(),
 		middlewares.Authorization("product", "update"),
 		r.productController.UpdateProduct)
-	v1.PUT("/payment", r.authentication.Verify(), r.productController.Payment)
+	v1.PATCH("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.PatchProduct)
+	v1.PUT("/:id/status", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.ChangeProductStatus)
+	v1.PUT("/:id/relations", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.SetProductRelations)
+	v1.PUT("/:id/restore", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.RestoreProduct)
+	v1.PUT("/:id/stock", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.SetStock)
+	v1.POST("/:id/stock/restock", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.productController.Restock)
+	v1.POST("/:id/stock/write-off", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.productController.WriteOff)
+	v1.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.productController.DeleteProduct)
+	v1.PUT("/payment", r.authentication.Verify(), r.reservationController.Payment)
+
+	categories := v1.Group("/categories")
+	categories.GET("/", r.categoryController.GetAll)
+	categories.GET("/tree", r.categoryController.GetTree)
+	categories.GET("/id/:id", r.categoryController.GetCategoryById)
+	categories.GET("/slug/:slug", r.categoryController.GetCategoryBySlug)
+	categories.POST("/", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.categoryController.AddCategory)
+	categories.PUT("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.categoryController.UpdateCategory)
+	categories.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.categoryController.DeleteCategory)
+
+	priceLists := v1.Group("/pricelists")
+	priceLists.GET("/", r.priceListController.GetAll)
+	priceLists.GET("/id/:id", r.priceListController.GetPriceListById)
+	priceLists.POST("/", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.priceListController.AddPriceList)
+	priceLists.PUT("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.priceListController.UpdatePriceList)
+	priceLists.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.priceListController.DeletePriceList)
+
+	attributes := v1.Group("/attributes")
+	attributes.GET("/", r.attributeController.GetAll)
+	attributes.GET("/id/:id", r.attributeController.GetAttributeById)
+	attributes.GET("/code/:code", r.attributeController.GetAttributeByCode)
+	attributes.POST("/", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.attributeController.AddAttribute)
+	attributes.PUT("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.attributeController.UpdateAttribute)
+	attributes.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.attributeController.DeleteAttribute)
+
+	brands := v1.Group("/brands")
+	brands.GET("/", r.brandController.GetAll)
+	brands.GET("/id/:id", r.brandController.GetBrandById)
+	brands.GET("/slug/:slug", r.brandController.GetBrandBySlug)
+	brands.POST("/", r.authentication.Verify(),
+		middlewares.Authorization("product", "create"),
+		r.brandController.AddBrand)
+	brands.PUT("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.brandController.UpdateBrand)
+	brands.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.brandController.DeleteBrand)
+
+	reviews := v1.Group("/reviews")
+	reviews.GET("/product/:id/:page/:size", r.reviewController.GetProductReviews)
+	reviews.GET("/admin/:page/:size", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.reviewController.GetAllAdmin)
+	reviews.POST("/", r.authentication.Verify(), r.reviewController.AddReview)
+	reviews.PUT("/:id/status", r.authentication.Verify(),
+		middlewares.Authorization("product", "update"),
+		r.reviewController.ModerateReview)
+	reviews.DELETE("/:id", r.authentication.Verify(),
+		middlewares.Authorization("product", "delete"),
+		r.reviewController.DeleteReview)
+
+	feeds := v1.Group("/feeds")
+	feeds.GET("/google.xml", r.feedController.GetGoogleFeed)
+	feeds.GET("/sitemap.xml", r.feedController.GetSitemap)
 
 	return router
 }
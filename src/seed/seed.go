@@ -50,6 +50,7 @@ func handler(command *product_command_handler.ProductCommandHandler) error {
 			Price:       product.Price,
 			Quantity:    product.Quantity,
 			Image:       product.Image,
+			Status:      models.ProductPublished,
 		}
 		_, err = command.CreateProductCommandHandler(ctx, createProductCommand)
 		if err != nil {
@@ -0,0 +1,25 @@
+package settings
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Load reads the same config file as the common config package into a viper instance of its own. The common package
+// resets the global instance when it reads the production .env file, dropping every section it does not know about,
+// so service sections such as "reservation" and "feed" are unmarshalled from here instead.
+func Load(production bool, path string) (*viper.Viper, error) {
+	settings := viper.New()
+	settings.AddConfigPath(path)
+	settings.SetConfigName("config-dev")
+	if production {
+		settings.SetConfigName("config-prod")
+	}
+	settings.SetConfigType("json")
+
+	err := settings.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,56 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"product/src/feed"
+	"time"
+
+	common_service "github.com/JohnSalazar/microservices-go-common/services"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+)
+
+type ProductFeedTask struct {
+	feed  *feed.Feed
+	email common_service.EmailService
+}
+
+func NewProductFeedTask(
+	feed *feed.Feed,
+	email common_service.EmailService,
+) *ProductFeedTask {
+	return &ProductFeedTask{
+		feed:  feed,
+		email: email,
+	}
+}
+
+func (task *ProductFeedTask) Run() {
+	ticker := time.NewTicker(2 * time.Second)
+	quit := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ctx := context.Background()
+				err := task.feed.Generate(ctx)
+				if err != nil {
+					_, span := trace.NewSpan(ctx, "tasks.ProductFeedTask")
+					msg := fmt.Sprintf("error task product feed: %s", err.Error())
+					trace.FailSpan(span, msg)
+					span.End()
+					log.Print(msg)
+					go task.email.SendSupportMessage(msg)
+					ticker.Reset(15 * time.Second)
+					break
+				}
+
+				ticker.Reset(time.Duration(task.feed.Config().MinutesToRegenerate) * time.Minute)
+			case <-quit:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,126 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	events "product/src/application/events/product"
+	product_repository "product/src/data/repositories/interfaces"
+	redis_product_repository "product/src/data/repositories/redis"
+	"product/src/models"
+	"product/src/nats/subjects"
+	"time"
+
+	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
+	common_service "github.com/JohnSalazar/microservices-go-common/services"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+	"github.com/google/uuid"
+)
+
+type ProductPromotionTask struct {
+	mongoRepository product_repository.PromotionRepository
+	redisRepository redis_product_repository.ProductRepository
+	email           common_service.EmailService
+	publisher       common_nats.Publisher
+}
+
+var timeToVerifyPromotions = 30 * time.Second
+
+func NewProductPromotionTask(
+	mongoRepository product_repository.PromotionRepository,
+	redisRepository redis_product_repository.ProductRepository,
+	email common_service.EmailService,
+	publisher common_nats.Publisher,
+) *ProductPromotionTask {
+	return &ProductPromotionTask{
+		mongoRepository: mongoRepository,
+		redisRepository: redisRepository,
+		email:           email,
+		publisher:       publisher,
+	}
+}
+
+func (task *ProductPromotionTask) Run() {
+	ticker := time.NewTicker(2 * time.Second)
+	quit := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ctx := context.Background()
+				err := task.verifyPromotions(ctx)
+				if err != nil {
+					_, span := trace.NewSpan(ctx, "tasks.ProductPromotionTask")
+					msg := fmt.Sprintf("error task product promotion: %s", err.Error())
+					trace.FailSpan(span, msg)
+					span.End()
+					log.Print(msg)
+					go task.email.SendSupportMessage(msg)
+				}
+
+				ticker.Reset(timeToVerifyPromotions)
+			case <-quit:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (task *ProductPromotionTask) verifyPromotions(ctx context.Context) error {
+	products, err := task.mongoRepository.GetPromoted(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, product := range products {
+		promotion := models.ActivePromotion(product.Promotions, now)
+		salePrice := models.SalePrice(product.Promotions, now)
+		if task.samePrice(product.SalePrice, salePrice) {
+			continue
+		}
+
+		err = task.mongoRepository.SetSalePrice(ctx, product.ID, salePrice)
+		if err != nil {
+			return err
+		}
+
+		product.SalePrice = salePrice
+		_, err = task.redisRepository.Update(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		priceChangedEvent := &events.ProductPriceChangedEvent{
+			AggregateID: product.ID,
+			MessageType: "product.price-changed",
+			Timestamp:   now,
+			ID:          product.ID,
+			Price:       product.Price,
+			SalePrice:   salePrice,
+		}
+		if promotion != nil {
+			priceChangedEvent.PromotionID = uuid.NullUUID{UUID: promotion.ID, Valid: true}
+		}
+
+		data, _ := json.Marshal(priceChangedEvent)
+		err = task.publisher.Publish(string(subjects.ProductPriceChanged), data)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("product %s price changed: %s", product.ID, now)
+	}
+
+	return nil
+}
+
+func (task *ProductPromotionTask) samePrice(current *models.Money, next *models.Money) bool {
+	if current == nil || next == nil {
+		return current == next
+	}
+
+	return current.Currency == next.Currency && current.Amount.Equal(next.Amount)
+}
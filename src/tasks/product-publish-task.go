@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	commands "product/src/application/commands/product"
+	product_repository "product/src/data/repositories/interfaces"
+	"product/src/models"
+	"product/src/nats/subjects"
+	"time"
+
+	common_nats "github.com/JohnSalazar/microservices-go-common/nats"
+	common_service "github.com/JohnSalazar/microservices-go-common/services"
+	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
+)
+
+type ProductPublishTask struct {
+	postgresRepository product_repository.ProductStatusRepository
+	email              common_service.EmailService
+	publisher          common_nats.Publisher
+}
+
+var timeToVerifyScheduled = 30 * time.Second
+
+func NewProductPublishTask(
+	postgresRepository product_repository.ProductStatusRepository,
+	email common_service.EmailService,
+	publisher common_nats.Publisher,
+) *ProductPublishTask {
+	return &ProductPublishTask{
+		postgresRepository: postgresRepository,
+		email:              email,
+		publisher:          publisher,
+	}
+}
+
+func (task *ProductPublishTask) Run() {
+	ticker := time.NewTicker(2 * time.Second)
+	quit := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ctx := context.Background()
+				err := task.publishScheduled(ctx)
+				if err != nil {
+					_, span := trace.NewSpan(ctx, "tasks.ProductPublishTask")
+					msg := fmt.Sprintf("error task product publish: %s", err.Error())
+					trace.FailSpan(span, msg)
+					span.End()
+					log.Print(msg)
+					go task.email.SendSupportMessage(msg)
+				}
+
+				ticker.Reset(timeToVerifyScheduled)
+			case <-quit:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (task *ProductPublishTask) publishScheduled(ctx context.Context) error {
+	now := time.Now().UTC()
+	products, err := task.postgresRepository.GetScheduled(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		publishCommand := &commands.ChangeProductStatusCommand{
+			AggregateID: product.ID,
+			MessageType: "product.publish",
+			Timestamp:   now,
+			ID:          product.ID,
+			Status:      models.ProductPublished,
+		}
+
+		data, _ := json.Marshal(publishCommand)
+		err = task.publisher.Publish(string(subjects.ProductPublishPostgres), data)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("product %s publish requested: %s", product.ID, now)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	product_repository "product/src/data/repositories/interfaces"
 	redis_product_repository "product/src/data/repositories/redis"
+	"product/src/models"
 	"sync"
 	"time"
 
@@ -56,7 +57,7 @@ func (task *ProductReloadCacheTask) Run() {
 				mLoading.Unlock()
 
 				ctx := context.Background()
-				products, err := task.mongoRepository.GetAll(ctx, "", 0, 0)
+				products, err := task.mongoRepository.GetAll(ctx, &models.ProductFilter{}, 0, 0)
 				if err != nil {
 					_, span := trace.NewSpan(ctx, "tasks.ProductReloadTask")
 					defer span.End()
@@ -16,6 +16,7 @@ import (
 	common_service "github.com/JohnSalazar/microservices-go-common/services"
 	trace "github.com/JohnSalazar/microservices-go-common/trace/otel"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type VerifyStoreTask interface {
@@ -24,9 +25,10 @@ type VerifyStoreTask interface {
 }
 
 type verifyStoreTask struct {
-	storePostgresRepository postgres_repository_interface.StoreRepository
-	email                   common_service.EmailService
-	publisher               common_nats.Publisher
+	storePostgresRepository       postgres_repository_interface.StoreRepository
+	reservationPostgresRepository postgres_repository_interface.ReservationRepository
+	email                         common_service.EmailService
+	publisher                     common_nats.Publisher
 }
 
 type taskStore struct {
@@ -36,20 +38,32 @@ type taskStore struct {
 
 func NewStoreTask(
 	storePostgresRepository postgres_repository_interface.StoreRepository,
+	reservationPostgresRepository postgres_repository_interface.ReservationRepository,
 	email common_service.EmailService,
 	publisher common_nats.Publisher,
 ) *verifyStoreTask {
 	return &verifyStoreTask{
-		storePostgresRepository: storePostgresRepository,
-		email:                   email,
-		publisher:               publisher,
+		storePostgresRepository:       storePostgresRepository,
+		reservationPostgresRepository: reservationPostgresRepository,
+		email:                         email,
+		publisher:                     publisher,
 	}
 }
 
+const expiredReservationsInterval = 1 * time.Minute
+
 var stores []*taskStore
 var loadedStore bool
+var expiredReservationsCheckedAt time.Time
 
 func (task *verifyStoreTask) AddStore(store *models.Store) {
+	for i := range stores {
+		if stores[i] != nil && stores[i].ID == store.ID {
+			stores[i].BookedAt = store.BookedAt
+			return
+		}
+	}
+
 	s := &taskStore{ID: store.ID, BookedAt: store.BookedAt}
 	stores = append(stores, s)
 }
@@ -57,6 +71,7 @@ func (task *verifyStoreTask) AddStore(store *models.Store) {
 func (task *verifyStoreTask) Run() {
 	if !loadedStore {
 		task.loadStore()
+		task.releaseExpiredReservations()
 	}
 
 	ticker := time.NewTicker(2 * time.Second)
@@ -95,6 +110,10 @@ func (task *verifyStoreTask) Run() {
 					task.clearStore()
 				}
 
+				if time.Since(expiredReservationsCheckedAt) >= expiredReservationsInterval {
+					task.releaseExpiredReservations()
+				}
+
 				// fmt.Printf("store success checked %s\n", time.Now().UTC())
 				ticker.Reset(5 * time.Second)
 			case <-quit:
@@ -120,6 +139,39 @@ func (task *verifyStoreTask) loadStore() {
 	loadedStore = true
 }
 
+// releaseExpiredReservations unbooks reservations that expired while their stores were not being watched,
+// e.g. while the service was down.
+func (task *verifyStoreTask) releaseExpiredReservations() {
+	ctx := context.Background()
+	expiredReservationsCheckedAt = time.Now().UTC()
+
+	reservations, err := task.reservationPostgresRepository.FindExpired(ctx, expiredReservationsCheckedAt)
+	if err != nil {
+		log.Printf("error loading expired reservations: %s", err.Error())
+		return
+	}
+
+	for _, reservation := range reservations {
+		orderID, err := primitive.ObjectIDFromHex(reservation.OrderID)
+		if err != nil {
+			log.Printf("error releasing reservation %s: %s", reservation.ID, err.Error())
+			continue
+		}
+
+		storeCommand := &command.UnbookStoreCommand{
+			OrderID: orderID,
+		}
+		data, _ := json.Marshal(storeCommand)
+		err = task.publisher.Publish(string(subjects.StoreUnbookPostgres), data)
+		if err != nil {
+			msg := fmt.Sprintf("error releasing reservation %s: %s", reservation.ID, err.Error())
+			log.Print(msg)
+			go task.email.SendSupportMessage(msg)
+			return
+		}
+	}
+}
+
 func (task *verifyStoreTask) clearStore() {
 	newStore := []*taskStore{}
 
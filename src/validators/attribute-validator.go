@@ -0,0 +1,105 @@
+package validators
+
+import (
+	"fmt"
+	"product/src/dtos"
+	"product/src/models"
+	"regexp"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+)
+
+var attributeCode = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type addAttribute struct {
+	Code string `from:"code" json:"code" validate:"required,max=100"`
+	Name string `from:"name" json:"name" validate:"required,max=200"`
+	Type string `from:"type" json:"type" validate:"required,oneof=string number boolean enum"`
+	Unit string `from:"unit" json:"unit,omitempty" validate:"max=20"`
+}
+
+type updateAttribute struct {
+	ID   uuid.UUID `from:"id" json:"id" validate:"required"`
+	Code string    `from:"code" json:"code" validate:"required,max=100"`
+	Name string    `from:"name" json:"name" validate:"required,max=200"`
+	Type string    `from:"type" json:"type" validate:"required,oneof=string number boolean enum"`
+	Unit string    `from:"unit" json:"unit,omitempty" validate:"max=20"`
+}
+
+func ValidateAddAttribute(fields *dtos.AddAttribute) interface{} {
+	addAttribute := addAttribute{
+		Code: fields.Code,
+		Name: fields.Name,
+		Type: fields.Type,
+		Unit: fields.Unit,
+	}
+
+	err := common_validator.Validate(addAttribute)
+	if err != nil {
+		return err
+	}
+
+	errors := validateAttributeDefinition(fields.Code, fields.Type, fields.Values)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func ValidateUpdateAttribute(fields *dtos.UpdateAttribute) interface{} {
+	updateAttribute := updateAttribute{
+		ID:   fields.ID,
+		Code: fields.Code,
+		Name: fields.Name,
+		Type: fields.Type,
+		Unit: fields.Unit,
+	}
+
+	err := common_validator.Validate(updateAttribute)
+	if err != nil {
+		return err
+	}
+
+	errors := validateAttributeDefinition(fields.Code, fields.Type, fields.Values)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func validateAttributeDefinition(code string, _type string, values []string) []string {
+	errors := []string{}
+
+	if !attributeCode.MatchString(code) {
+		errors = append(errors, "code must contain only lowercase letters, digits and underscores and start with a letter")
+	}
+
+	if _type != models.AttributeEnum {
+		if len(values) > 0 {
+			errors = append(errors, "values are only allowed for enum attributes")
+		}
+		return errors
+	}
+
+	if len(values) == 0 {
+		errors = append(errors, "values are required for enum attributes")
+	}
+
+	unique := map[string]bool{}
+	for _, value := range values {
+		if len(value) == 0 {
+			errors = append(errors, "enum values cannot be empty")
+			continue
+		}
+
+		if unique[value] {
+			errors = append(errors, fmt.Sprintf("enum value %s is duplicated", value))
+		}
+		unique[value] = true
+	}
+
+	return errors
+}
@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"product/src/dtos"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+)
+
+type addBrand struct {
+	Name        string `from:"name" json:"name" validate:"required,max=200"`
+	Slug        string `from:"slug" json:"slug" validate:"required,max=250"`
+	Logo        string `from:"logo" json:"logo,omitempty" validate:"omitempty,url,max=500"`
+	Description string `from:"description" json:"description,omitempty" validate:"max=2000"`
+}
+
+type updateBrand struct {
+	ID          uuid.UUID `from:"id" json:"id" validate:"required"`
+	Name        string    `from:"name" json:"name" validate:"required,max=200"`
+	Slug        string    `from:"slug" json:"slug" validate:"required,max=250"`
+	Logo        string    `from:"logo" json:"logo,omitempty" validate:"omitempty,url,max=500"`
+	Description string    `from:"description" json:"description,omitempty" validate:"max=2000"`
+}
+
+func ValidateAddBrand(fields *dtos.AddBrand) interface{} {
+	addBrand := addBrand{
+		Name:        fields.Name,
+		Slug:        fields.Slug,
+		Logo:        fields.Logo,
+		Description: fields.Description,
+	}
+
+	err := common_validator.Validate(addBrand)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateUpdateBrand(fields *dtos.UpdateBrand) interface{} {
+	updateBrand := updateBrand{
+		ID:          fields.ID,
+		Name:        fields.Name,
+		Slug:        fields.Slug,
+		Logo:        fields.Logo,
+		Description: fields.Description,
+	}
+
+	err := common_validator.Validate(updateBrand)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
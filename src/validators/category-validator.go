@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"product/src/dtos"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+)
+
+type addCategory struct {
+	Name        string `from:"name" json:"name" validate:"required,max=200"`
+	Slug        string `from:"slug" json:"slug" validate:"required,max=250"`
+	Description string `from:"description" json:"description,omitempty" validate:"max=2000"`
+}
+
+type updateCategory struct {
+	ID          uuid.UUID `from:"id" json:"id" validate:"required"`
+	Name        string    `from:"name" json:"name" validate:"required,max=200"`
+	Slug        string    `from:"slug" json:"slug" validate:"required,max=250"`
+	Description string    `from:"description" json:"description,omitempty" validate:"max=2000"`
+}
+
+func ValidateAddCategory(fields *dtos.AddCategory) interface{} {
+	addCategory := addCategory{
+		Name:        fields.Name,
+		Slug:        fields.Slug,
+		Description: fields.Description,
+	}
+
+	err := common_validator.Validate(addCategory)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateUpdateCategory(fields *dtos.UpdateCategory) interface{} {
+	updateCategory := updateCategory{
+		ID:          fields.ID,
+		Name:        fields.Name,
+		Slug:        fields.Slug,
+		Description: fields.Description,
+	}
+
+	err := common_validator.Validate(updateCategory)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
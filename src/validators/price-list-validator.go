@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"product/src/dtos"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+)
+
+type addPriceList struct {
+	Code     string `from:"code" json:"code" validate:"required,max=100"`
+	Currency string `from:"currency" json:"currency" validate:"required,len=3,uppercase"`
+	Channel  string `from:"channel" json:"channel,omitempty" validate:"max=100"`
+}
+
+type updatePriceList struct {
+	ID       uuid.UUID `from:"id" json:"id" validate:"required"`
+	Code     string    `from:"code" json:"code" validate:"required,max=100"`
+	Currency string    `from:"currency" json:"currency" validate:"required,len=3,uppercase"`
+	Channel  string    `from:"channel" json:"channel,omitempty" validate:"max=100"`
+}
+
+func ValidateAddPriceList(fields *dtos.AddPriceList) interface{} {
+	addPriceList := addPriceList{
+		Code:     fields.Code,
+		Currency: fields.Currency,
+		Channel:  fields.Channel,
+	}
+
+	err := common_validator.Validate(addPriceList)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateUpdatePriceList(fields *dtos.UpdatePriceList) interface{} {
+	updatePriceList := updatePriceList{
+		ID:       fields.ID,
+		Code:     fields.Code,
+		Currency: fields.Currency,
+		Channel:  fields.Channel,
+	}
+
+	err := common_validator.Validate(updatePriceList)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
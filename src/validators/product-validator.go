@@ -1,18 +1,23 @@
 package validators
 
 import (
+	"fmt"
 	"product/src/dtos"
+	"product/src/models"
 
 	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type addProduct struct {
-	Name        string  `from:"name" json:"name" validate:"required,max=500"`
-	Slug        string  `from:"slug" json:"slug" validate:"required,max=600"`
-	Description string  `from:"description" json:"description,omitempty" validate:"max=10000"`
-	Price       float32 `from:"price" json:"price" validate:"required,gte=1"`
-	Quantity    uint    `from:"quantity" json:"quantity" validate:"required,gte=1"`
+	Name        string `from:"name" json:"name" validate:"required,max=500"`
+	Slug        string `from:"slug" json:"slug" validate:"required,max=600"`
+	Description string `from:"description" json:"description,omitempty" validate:"max=10000"`
+	Currency    string `from:"currency" json:"currency" validate:"required,len=3,uppercase"`
+	Status      string `from:"status" json:"status" validate:"required,oneof=draft scheduled published archived"`
+	Type        string `from:"type" json:"type" validate:"required,oneof=simple bundle"`
+	Quantity    uint   `from:"quantity" json:"quantity" validate:"required_unless=Type bundle"`
 }
 
 type updateProduct struct {
@@ -20,7 +25,34 @@ type updateProduct struct {
 	Name        string    `from:"name" json:"name" validate:"max=500"`
 	Slug        string    `from:"slug" json:"slug" validate:"required,max=600"`
 	Description string    `from:"description" json:"description,omitempty" validate:"max=10000"`
-	Price       float32   `from:"price" json:"price" validate:"required,gte=1"`
+	Currency    string    `from:"currency" json:"currency" validate:"required,len=3,uppercase"`
+	Status      string    `from:"status" json:"status" validate:"required,oneof=draft scheduled published archived"`
+	Type        string    `from:"type" json:"type" validate:"required,oneof=simple bundle"`
+}
+
+type changeProductStatus struct {
+	ID     uuid.UUID `from:"id" json:"id" validate:"required"`
+	Status string    `from:"status" json:"status" validate:"required,oneof=draft scheduled published archived"`
+}
+
+type setProductRelations struct {
+	ID uuid.UUID `from:"id" json:"id" validate:"required"`
+}
+
+type importProducts struct {
+	ID     uuid.UUID `from:"id" json:"id" validate:"required"`
+	Format string    `from:"format" json:"format" validate:"required,oneof=csv json ndjson"`
+	Size   int       `from:"size" json:"size" validate:"required"`
+}
+
+type productTranslation struct {
+	Name        string `from:"name" json:"name" validate:"required,max=500"`
+	Slug        string `from:"slug" json:"slug" validate:"required,max=600"`
+	Description string `from:"description" json:"description,omitempty" validate:"max=10000"`
+}
+
+type productVariant struct {
+	SKU string `from:"sku" json:"sku" validate:"required,max=100"`
 }
 
 func ValidateAddProduct(fields *dtos.AddProduct) interface{} {
@@ -28,7 +60,9 @@ func ValidateAddProduct(fields *dtos.AddProduct) interface{} {
 		Name: fields.Name,
 		Slug: fields.Slug,
 		// Description: fields.Description,
-		Price:    fields.Price,
+		Currency: fields.Price.Currency,
+		Status:   fields.Status,
+		Type:     fields.Type,
 		Quantity: fields.Quantity,
 	}
 
@@ -37,6 +71,17 @@ func ValidateAddProduct(fields *dtos.AddProduct) interface{} {
 		return err
 	}
 
+	errors := validatePrice("price", fields.Price)
+	errors = append(errors, validateVariants(fields.Price.Currency, fields.Options, fields.Variants)...)
+	errors = append(errors, validatePrices(fields.Prices)...)
+	errors = append(errors, validatePromotions(fields.Price, fields.Prices, fields.Promotions)...)
+	errors = append(errors, validateTranslations(fields.Translations)...)
+	errors = append(errors, validateAttributes(fields.Attributes)...)
+	errors = append(errors, validateComponents(fields.ID, fields.Type, fields.Variants, fields.Components)...)
+	if len(errors) > 0 {
+		return errors
+	}
+
 	return nil
 }
 
@@ -46,7 +91,9 @@ func ValidateUpdateProduct(fields *dtos.UpdateProduct) interface{} {
 		Name: fields.Name,
 		Slug: fields.Slug,
 		// Description: fields.Description,
-		Price: fields.Price,
+		Currency: fields.Price.Currency,
+		Status:   fields.Status,
+		Type:     fields.Type,
 	}
 
 	err := common_validator.Validate(updateProduct)
@@ -54,5 +101,331 @@ func ValidateUpdateProduct(fields *dtos.UpdateProduct) interface{} {
 		return err
 	}
 
+	errors := validatePrice("price", fields.Price)
+	errors = append(errors, validateVariants(fields.Price.Currency, fields.Options, fields.Variants)...)
+	errors = append(errors, validatePrices(fields.Prices)...)
+	errors = append(errors, validatePromotions(fields.Price, fields.Prices, fields.Promotions)...)
+	errors = append(errors, validateTranslations(fields.Translations)...)
+	errors = append(errors, validateAttributes(fields.Attributes)...)
+	errors = append(errors, validateComponents(fields.ID, fields.Type, fields.Variants, fields.Components)...)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func ValidateChangeProductStatus(fields *dtos.ChangeProductStatus) interface{} {
+	changeProductStatus := changeProductStatus{
+		ID:     fields.ID,
+		Status: fields.Status,
+	}
+
+	err := common_validator.Validate(changeProductStatus)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func ValidateSetProductRelations(fields *dtos.SetProductRelations) interface{} {
+	setProductRelations := setProductRelations{
+		ID: fields.ID,
+	}
+
+	err := common_validator.Validate(setProductRelations)
+	if err != nil {
+		return err
+	}
+
+	errors := []string{}
+	relations := map[string]bool{}
+	for _, relation := range fields.Relations {
+		if relation.Type != models.RelationRelated && relation.Type != models.RelationAccessory && relation.Type != models.RelationUpsell {
+			errors = append(errors, fmt.Sprintf("relation type %s is not supported", relation.Type))
+			continue
+		}
+
+		if relation.ProductID == fields.ID {
+			errors = append(errors, "product cannot be related to itself")
+		}
+
+		key := relation.Type + relation.ProductID.String()
+		if relations[key] {
+			errors = append(errors, fmt.Sprintf("%s relation %v is duplicated", relation.Type, relation.ProductID))
+		}
+		relations[key] = true
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func ValidateImportProducts(fields *dtos.ImportProducts) interface{} {
+	importProducts := importProducts{
+		ID:     fields.ID,
+		Format: fields.Format,
+		Size:   fields.Size,
+	}
+
+	err := common_validator.Validate(importProducts)
+	if err != nil {
+		return err
+	}
+
+	if fields.Size > models.ImportMaxSize {
+		return []string{fmt.Sprintf("import file must not exceed %d bytes", models.ImportMaxSize)}
+	}
+
+	return nil
+}
+
+func validatePrice(field string, price models.Money) []string {
+	errors := []string{}
+
+	if price.Amount.LessThan(decimal.NewFromInt(1)) {
+		errors = append(errors, fmt.Sprintf("%s must be 1 or greater", field))
+	}
+
+	if !price.Amount.Equal(price.Amount.Round(2)) {
+		errors = append(errors, fmt.Sprintf("%s must have at most 2 decimal places", field))
+	}
+
+	return errors
+}
+
+func validatePrices(prices []*models.ProductPrice) []string {
+	errors := []string{}
+
+	priceLists := map[uuid.UUID]bool{}
+	for _, price := range prices {
+		if price.PriceListID == uuid.Nil {
+			errors = append(errors, "price list id is required")
+			continue
+		}
+
+		if priceLists[price.PriceListID] {
+			errors = append(errors, fmt.Sprintf("price list %s is duplicated", price.PriceListID))
+		}
+		priceLists[price.PriceListID] = true
+
+		errors = append(errors, validatePrice(fmt.Sprintf("price list %s price", price.PriceListID), price.Price)...)
+	}
+
+	return errors
+}
+
+// validatePromotions checks each promotion against the price it discounts, the base price or the product price
+// in the promotion's price list. Promotions only overlap within the same price list.
+func validatePromotions(price models.Money, prices []*models.ProductPrice, promotions []*models.ProductPromotion) []string {
+	errors := []string{}
+
+	for i, promotion := range promotions {
+		field := fmt.Sprintf("promotion %d price", i+1)
+		errors = append(errors, validatePrice(field, promotion.Price)...)
+
+		scoped := &price
+		if promotion.PriceListID.Valid {
+			scoped = models.ListPrice(prices, promotion.PriceListID.UUID)
+			if scoped == nil {
+				errors = append(errors, fmt.Sprintf("promotion %d price list %s has no product price", i+1, promotion.PriceListID.UUID))
+				continue
+			}
+		}
+
+		if len(scoped.Currency) > 0 && promotion.Price.Currency != scoped.Currency {
+			errors = append(errors, fmt.Sprintf("%s must be in %s", field, scoped.Currency))
+		}
+
+		if !promotion.Price.Amount.LessThan(scoped.Amount) {
+			errors = append(errors, fmt.Sprintf("%s must be lower than the product price", field))
+		}
+
+		if promotion.StartsAt.IsZero() || !promotion.EndsAt.After(promotion.StartsAt) {
+			errors = append(errors, fmt.Sprintf("promotion %d must end after it starts", i+1))
+			continue
+		}
+
+		for j, other := range promotions[:i] {
+			if other.PriceListID != promotion.PriceListID {
+				continue
+			}
+
+			if promotion.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(promotion.EndsAt) {
+				errors = append(errors, fmt.Sprintf("promotion %d overlaps promotion %d", i+1, j+1))
+			}
+		}
+	}
+
+	return errors
+}
+
+func validateTranslations(translations []*models.ProductTranslation) []string {
+	errors := []string{}
+
+	locales := map[string]bool{}
+	for _, translation := range translations {
+		if _, ok := models.Locales[translation.Locale]; !ok || translation.Locale == models.DefaultLocale {
+			errors = append(errors, fmt.Sprintf("locale %s is not supported for translations", translation.Locale))
+			continue
+		}
+
+		if locales[translation.Locale] {
+			errors = append(errors, fmt.Sprintf("translation %s is duplicated", translation.Locale))
+		}
+		locales[translation.Locale] = true
+
+		err := common_validator.Validate(productTranslation{
+			Name:        translation.Name,
+			Slug:        translation.Slug,
+			Description: translation.Description,
+		})
+		if err != nil {
+			errors = append(errors, err.([]string)...)
+		}
+	}
+
+	return errors
+}
+
+func validateAttributes(attributes []*models.ProductAttribute) []string {
+	errors := []string{}
+
+	codes := map[string]bool{}
+	for _, attribute := range attributes {
+		if codes[attribute.Code] {
+			errors = append(errors, fmt.Sprintf("attribute %s is duplicated", attribute.Code))
+		}
+		codes[attribute.Code] = true
+
+		valid := false
+		switch attribute.Type {
+		case models.AttributeNumber:
+			_, valid = attribute.Value.(float64)
+		case models.AttributeBoolean:
+			_, valid = attribute.Value.(bool)
+		case models.AttributeString, models.AttributeEnum:
+			var value string
+			value, valid = attribute.Value.(string)
+			valid = valid && len(value) > 0
+		}
+		if !valid {
+			errors = append(errors, fmt.Sprintf("attribute %s must be a %s value", attribute.Code, attribute.Type))
+			continue
+		}
+
+		if attribute.Type == models.AttributeEnum && attribute.Definition != nil && !attribute.Definition.Allows(attribute.Value.(string)) {
+			errors = append(errors, fmt.Sprintf("attribute %s does not allow the value %s", attribute.Code, attribute.Value))
+		}
+	}
+
+	return errors
+}
+
+func validateComponents(productID uuid.UUID, _type string, variants []*models.ProductVariant, components []*models.ProductComponent) []string {
+	errors := []string{}
+
+	if _type != models.ProductBundle {
+		if len(components) > 0 {
+			errors = append(errors, "components are only allowed for bundle products")
+		}
+		return errors
+	}
+
+	if len(variants) > 0 {
+		errors = append(errors, "bundle products cannot have variants")
+	}
+
+	if len(components) == 0 {
+		errors = append(errors, "components are required for bundle products")
+	}
+
+	products := map[uuid.UUID]bool{}
+	for _, component := range components {
+		if component.ProductID == productID {
+			errors = append(errors, "bundle cannot contain itself")
+		}
+
+		if products[component.ProductID] {
+			errors = append(errors, fmt.Sprintf("component %v is duplicated", component.ProductID))
+		}
+		products[component.ProductID] = true
+
+		if component.Quantity < 1 {
+			errors = append(errors, fmt.Sprintf("component %v quantity must be 1 or greater", component.ProductID))
+		}
+	}
+
+	return errors
+}
+
+func validateVariants(currency string, options []*models.ProductOption, variants []*models.ProductVariant) []string {
+	errors := []string{}
+
+	if len(variants) > 0 && len(options) == 0 {
+		return append(errors, "options are required when the product has variants")
+	}
+
+	axes := map[string]map[string]bool{}
+	for _, option := range options {
+		if len(option.Name) == 0 || len(option.Values) == 0 {
+			errors = append(errors, "option name and values are required")
+			continue
+		}
+
+		axes[option.Name] = map[string]bool{}
+		for _, value := range option.Values {
+			axes[option.Name][value] = true
+		}
+	}
+
+	skus := map[string]bool{}
+	combinations := map[string]bool{}
+	for _, variant := range variants {
+		err := common_validator.Validate(productVariant{SKU: variant.SKU})
+		if err != nil {
+			errors = append(errors, err.([]string)...)
+			continue
+		}
+
+		if skus[variant.SKU] {
+			errors = append(errors, fmt.Sprintf("sku %s is duplicated", variant.SKU))
+		}
+		skus[variant.SKU] = true
+
+		if len(variant.Options) != len(axes) {
+			errors = append(errors, fmt.Sprintf("sku %s must define a value for every option", variant.SKU))
+			continue
+		}
+
+		combination := ""
+		for _, option := range options {
+			value, ok := variant.Options[option.Name]
+			if !ok || !axes[option.Name][value] {
+				errors = append(errors, fmt.Sprintf("sku %s has an invalid value for option %s", variant.SKU, option.Name))
+				break
+			}
+			combination += option.Name + "=" + value + ";"
+		}
+
+		if combinations[combination] {
+			errors = append(errors, fmt.Sprintf("sku %s duplicates the options of another variant", variant.SKU))
+		}
+		combinations[combination] = true
+
+		if variant.Price != nil {
+			errors = append(errors, validatePrice(fmt.Sprintf("sku %s price", variant.SKU), *variant.Price)...)
+
+			if variant.Price.Currency != currency {
+				errors = append(errors, fmt.Sprintf("sku %s price must be in %s", variant.SKU, currency))
+			}
+		}
+
+		errors = append(errors, validatePrices(variant.Prices)...)
+	}
+
+	return errors
+}
@@ -0,0 +1,163 @@
+package validators
+
+import (
+	"os"
+	"product/src/models"
+	"testing"
+	"time"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestMain(m *testing.M) {
+	common_validator.NewValidator("en")
+	os.Exit(m.Run())
+}
+
+func money(amount string, currency string) models.Money {
+	return models.NewMoney(decimal.RequireFromString(amount), currency)
+}
+
+func TestValidatePrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		price  models.Money
+		errors int
+	}{
+		{"valid", money("10.50", "BRL"), 0},
+		{"minimum", money("1", "BRL"), 0},
+		{"below minimum", money("0.99", "BRL"), 1},
+		{"too many decimals", money("10.505", "BRL"), 1},
+		{"below minimum and too many decimals", money("0.001", "BRL"), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := validatePrice("price", test.price)
+			if len(errors) != test.errors {
+				t.Errorf("errors = %v, want %d", errors, test.errors)
+			}
+		})
+	}
+}
+
+func TestValidatePromotionsCurrency(t *testing.T) {
+	startsAt := time.Now().UTC()
+	endsAt := startsAt.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		promotion models.Money
+		want      string
+	}{
+		{"same currency", money("9.00", "BRL"), ""},
+		{"currency mismatch", money("9.00", "USD"), "promotion 1 price must be in BRL"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := validatePromotions(money("10.00", "BRL"), nil, []*models.ProductPromotion{{
+				Price:    test.promotion,
+				StartsAt: startsAt,
+				EndsAt:   endsAt,
+			}})
+
+			if len(test.want) == 0 && len(errors) > 0 {
+				t.Errorf("errors = %v, want none", errors)
+			}
+
+			if len(test.want) > 0 && !contains(errors, test.want) {
+				t.Errorf("errors = %v, want %q", errors, test.want)
+			}
+		})
+	}
+}
+
+func TestValidatePromotionsPriceList(t *testing.T) {
+	startsAt := time.Now().UTC()
+	endsAt := startsAt.Add(time.Hour)
+	usd := uuid.New()
+	eur := uuid.New()
+	prices := []*models.ProductPrice{{PriceListID: usd, Price: money("20.00", "USD")}}
+
+	tests := []struct {
+		name       string
+		promotions []*models.ProductPromotion
+		want       string
+	}{
+		{"list promotion", []*models.ProductPromotion{
+			{PriceListID: uuid.NullUUID{UUID: usd, Valid: true}, Price: money("15.00", "USD"), StartsAt: startsAt, EndsAt: endsAt},
+		}, ""},
+		{"list currency mismatch", []*models.ProductPromotion{
+			{PriceListID: uuid.NullUUID{UUID: usd, Valid: true}, Price: money("15.00", "BRL"), StartsAt: startsAt, EndsAt: endsAt},
+		}, "promotion 1 price must be in USD"},
+		{"not lower than list price", []*models.ProductPromotion{
+			{PriceListID: uuid.NullUUID{UUID: usd, Valid: true}, Price: money("25.00", "USD"), StartsAt: startsAt, EndsAt: endsAt},
+		}, "promotion 1 price must be lower than the product price"},
+		{"list without product price", []*models.ProductPromotion{
+			{PriceListID: uuid.NullUUID{UUID: eur, Valid: true}, Price: money("15.00", "EUR"), StartsAt: startsAt, EndsAt: endsAt},
+		}, "promotion 1 price list " + eur.String() + " has no product price"},
+		{"different lists may overlap", []*models.ProductPromotion{
+			{Price: money("9.00", "BRL"), StartsAt: startsAt, EndsAt: endsAt},
+			{PriceListID: uuid.NullUUID{UUID: usd, Valid: true}, Price: money("15.00", "USD"), StartsAt: startsAt, EndsAt: endsAt},
+		}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := validatePromotions(money("10.00", "BRL"), prices, test.promotions)
+
+			if len(test.want) == 0 && len(errors) > 0 {
+				t.Errorf("errors = %v, want none", errors)
+			}
+
+			if len(test.want) > 0 && !contains(errors, test.want) {
+				t.Errorf("errors = %v, want %q", errors, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateVariantsCurrency(t *testing.T) {
+	options := []*models.ProductOption{{Name: "size", Values: []string{"M"}}}
+
+	tests := []struct {
+		name  string
+		price *models.Money
+		want  string
+	}{
+		{"no variant price", nil, ""},
+		{"same currency", &models.Money{Amount: decimal.RequireFromString("12.00"), Currency: "BRL"}, ""},
+		{"currency mismatch", &models.Money{Amount: decimal.RequireFromString("12.00"), Currency: "EUR"}, "sku SKU-M price must be in BRL"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := validateVariants("BRL", options, []*models.ProductVariant{{
+				SKU:     "SKU-M",
+				Options: map[string]string{"size": "M"},
+				Price:   test.price,
+			}})
+
+			if len(test.want) == 0 && len(errors) > 0 {
+				t.Errorf("errors = %v, want none", errors)
+			}
+
+			if len(test.want) > 0 && !contains(errors, test.want) {
+				t.Errorf("errors = %v, want %q", errors, test.want)
+			}
+		})
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"product/src/dtos"
+
+	common_validator "github.com/JohnSalazar/microservices-go-common/validators"
+	"github.com/google/uuid"
+)
+
+type addReview struct {
+	ProductID uuid.UUID `from:"productid" json:"productid" validate:"required"`
+	UserID    string    `from:"userid" json:"userid" validate:"required,len=24,hexadecimal"`
+	Rating    uint      `from:"rating" json:"rating" validate:"required,min=1,max=5"`
+	Title     string    `from:"title" json:"title,omitempty" validate:"max=200"`
+	Comment   string    `from:"comment" json:"comment,omitempty" validate:"max=4000"`
+}
+
+type moderateReview struct {
+	ID     uuid.UUID `from:"id" json:"id" validate:"required"`
+	Status string    `from:"status" json:"status" validate:"required,oneof=approved rejected"`
+}
+
+func ValidateAddReview(fields *dtos.AddReview) interface{} {
+	addReview := addReview{
+		ProductID: fields.ProductID,
+		UserID:    fields.UserID,
+		Rating:    fields.Rating,
+		Title:     fields.Title,
+		Comment:   fields.Comment,
+	}
+
+	err := common_validator.Validate(addReview)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateModerateReview(fields *dtos.ModerateReview) interface{} {
+	moderateReview := moderateReview{
+		ID:     fields.ID,
+		Status: fields.Status,
+	}
+
+	err := common_validator.Validate(moderateReview)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
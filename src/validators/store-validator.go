@@ -14,6 +14,17 @@ type addStore struct {
 	Quantity  uint      `from:"quantity" json:"quantity" validate:"required,gte=1"`
 }
 
+type writeOffStore struct {
+	ProductID uuid.UUID `from:"productid" json:"productid" validate:"required"`
+	Quantity  uint      `from:"quantity" json:"quantity" validate:"required,gte=1"`
+	Reason    string    `from:"reason" json:"reason" validate:"required,max=255"`
+}
+
+type setStock struct {
+	ProductID uuid.UUID `from:"productid" json:"productid" validate:"required"`
+	Reason    string    `from:"reason" json:"reason" validate:"max=255"`
+}
+
 type bookStore struct {
 	Products []*models.Product `from:"products" json:"products" validate:"required"`
 }
@@ -41,6 +52,35 @@ func ValidateAddStore(fields *dtos.AddStore) interface{} {
 	return nil
 }
 
+func ValidateWriteOffStore(fields *dtos.WriteOffStore) interface{} {
+	writeOffStore := writeOffStore{
+		ProductID: fields.ProductID,
+		Quantity:  fields.Quantity,
+		Reason:    fields.Reason,
+	}
+
+	err := common_validator.Validate(writeOffStore)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateSetStock(fields *dtos.SetStock) interface{} {
+	setStock := setStock{
+		ProductID: fields.ProductID,
+		Reason:    fields.Reason,
+	}
+
+	err := common_validator.Validate(setStock)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func ValidateBookStore(fields *dtos.BookStore) interface{} {
 	bookStore := bookStore{
 		Products: fields.Products,